@@ -11,6 +11,7 @@ export const EVENT_DEBOUNCE = 250;
 
 export const SEARCH_STORE_INSERT_CHANNEL = 'store.insert';
 export const SEARCH_STORE_REMOVE_CHANNEL = 'store.remove';
+export const SEARCH_STORE_BATCH_CHANNEL = 'store.batch';
 
 export const APPLICATION_LOG_CHANNEL = 'application.log';
 export const APPLICATION_ARGUMENT_CHANNEL = 'application.argument';
@@ -58,6 +59,14 @@ export const setupGlobalEventListeners = (logStore: LogStore, toastStore: ToastS
         changeDetectedDebounce();
     });
 
+    EventsOn(SEARCH_STORE_BATCH_CHANNEL, (data: { inserted?: string[], removed?: string[], indexTypes: string[] }) => {
+        if (data.indexTypes.every((indexType) => indexType === 'operation')) {
+            return;
+        }
+
+        changeDetectedDebounce();
+    });
+
     // Emit a ready event for the backend to listen for.
     EventsEmit('ready');
     
@@ -80,6 +89,9 @@ export const tearDownGlobalEventListeners = () => {
     // Remove search store listener
     EventsOff(SEARCH_STORE_REMOVE_CHANNEL);
 
+    // Remove search store listener
+    EventsOff(SEARCH_STORE_BATCH_CHANNEL);
+
     // Remove launch arguments listener
     EventsOff(APPLICATION_ARGUMENT_CHANNEL);
 }
\ No newline at end of file
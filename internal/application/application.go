@@ -165,6 +165,9 @@ func (a *Application) Execute() error {
 		EnumBind: []interface{}{
 			enums.PackageStates,
 			enums.PackageTypes,
+			enums.ProjectStates,
+			enums.ArchiveFormats,
+			enums.ProjectSorts,
 		},
 		MinHeight:        580,
 		MinWidth:         800,
@@ -0,0 +1,34 @@
+package application
+
+import "github.com/rocketblend/rocketblend-desktop/internal/application/types"
+
+type (
+	GetStoreChangesOpts struct {
+		SinceRevision uint64 `json:"sinceRevision"`
+	}
+
+	GetStoreChangesResult struct {
+		Revision uint64               `json:"revision"`
+		Changes  []*types.StoreChange `json:"changes"`
+		Reset    bool                 `json:"reset"`
+	}
+)
+
+// GetStoreChanges returns what changed in the store after the given revision, so the frontend can catch up
+// after reconnecting or reloading without fetching everything again.
+func (d *Driver) GetStoreChanges(opts GetStoreChangesOpts) (*GetStoreChangesResult, error) {
+	result, err := d.store.Changes(d.ctx, opts.SinceRevision)
+	if err != nil {
+		d.logger.Error("failed to get store changes", map[string]interface{}{
+			"error":         err.Error(),
+			"sinceRevision": opts.SinceRevision,
+		})
+		return nil, err
+	}
+
+	return &GetStoreChangesResult{
+		Revision: result.Revision,
+		Changes:  result.Changes,
+		Reset:    result.Reset,
+	}, nil
+}
@@ -1,7 +1,13 @@
 package application
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/watcher"
 	rbtypes "github.com/rocketblend/rocketblend/pkg/types"
 )
 
@@ -20,12 +26,42 @@ type (
 		RocketBlendConfigPath string `json:"rocketblendConfigPath"`
 	}
 
+	ProjectRoot struct {
+		Path           string `json:"path"`
+		Label          string `json:"label"`
+		FollowSymlinks bool   `json:"followSymlinks"`
+	}
+
 	Preferences struct {
+		WatchPath    string        `json:"watchPath"`
+		ProjectRoots []ProjectRoot `json:"projectRoots"`
+		Feature      Feature       `json:"feature"`
+	}
+
+	UpdatePreferencesOpts struct {
 		WatchPath string  `json:"watchPath"`
 		Feature   Feature `json:"feature"`
 	}
 
-	UpdatePreferencesOpts Preferences
+	AddProjectRootOpts struct {
+		Path           string `json:"path"`
+		Label          string `json:"label"`
+		FollowSymlinks bool   `json:"followSymlinks"`
+	}
+
+	UpdateProjectRootOpts struct {
+		Path           string `json:"path"`
+		Label          string `json:"label"`
+		FollowSymlinks bool   `json:"followSymlinks"`
+	}
+
+	RemoveProjectRootOpts struct {
+		Path string `json:"path"`
+	}
+
+	ReorderProjectRootsOpts struct {
+		Paths []string `json:"paths"`
+	}
 )
 
 func (d *Driver) GetPreferences() (*Preferences, error) {
@@ -35,12 +71,22 @@ func (d *Driver) GetPreferences() (*Preferences, error) {
 	}
 
 	watchPath := ""
-	if len(aConfig.Project.Paths) > 0 {
-		watchPath = aConfig.Project.Paths[0]
+	if len(aConfig.Project.Roots) > 0 {
+		watchPath = aConfig.Project.Roots[0].Path
+	}
+
+	roots := make([]ProjectRoot, 0, len(aConfig.Project.Roots))
+	for _, root := range aConfig.Project.Roots {
+		roots = append(roots, ProjectRoot{
+			Path:           root.Path,
+			Label:          root.Label,
+			FollowSymlinks: root.FollowSymlinks,
+		})
 	}
 
 	return &Preferences{
-		WatchPath: watchPath,
+		WatchPath:    watchPath,
+		ProjectRoots: roots,
 		Feature: Feature{
 			Addon:     aConfig.Feature.Addon,
 			Developer: aConfig.Feature.Developer,
@@ -54,7 +100,26 @@ func (d *Driver) UpdatePreferences(opts UpdatePreferencesOpts) error {
 		return err
 	}
 
-	config.Project.Paths = []string{opts.WatchPath}
+	// The watch path only replaces the primary project root, any additional roots are kept.
+	roots := config.Project.Roots
+	if opts.WatchPath != "" {
+		primary := types.ProjectRootConfig{
+			Path:  filepath.Clean(opts.WatchPath),
+			Label: filepath.Base(opts.WatchPath),
+		}
+
+		if len(roots) == 0 {
+			roots = []types.ProjectRootConfig{primary}
+		} else if roots[0].Path != primary.Path {
+			roots = append([]types.ProjectRootConfig{primary}, roots[1:]...)
+		}
+	}
+
+	if err := validateProjectRoots(roots); err != nil {
+		return err
+	}
+
+	config.Project.Roots = roots
 	config.Feature.Addon = opts.Feature.Addon
 	config.Feature.Developer = opts.Feature.Developer
 
@@ -69,6 +134,128 @@ func (d *Driver) UpdatePreferences(opts UpdatePreferencesOpts) error {
 	return nil
 }
 
+// AddProjectRoot appends a new project root to the list of watched roots.
+func (d *Driver) AddProjectRoot(opts AddProjectRootOpts) error {
+	if opts.Path == "" {
+		return errors.New("project root path is required")
+	}
+
+	info, err := os.Stat(opts.Path)
+	if err != nil {
+		return fmt.Errorf("invalid project root: %w", err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("invalid project root: %s is not a directory", opts.Path)
+	}
+
+	label := opts.Label
+	if label == "" {
+		label = filepath.Base(opts.Path)
+	}
+
+	if err := d.updateProjectRoots(func(roots []types.ProjectRootConfig) ([]types.ProjectRootConfig, error) {
+		return append(roots, types.ProjectRootConfig{
+			Path:           filepath.Clean(opts.Path),
+			Label:          label,
+			FollowSymlinks: opts.FollowSymlinks,
+		}), nil
+	}); err != nil {
+		d.logger.Error("failed to add project root", map[string]interface{}{
+			"error": err.Error(),
+			"path":  opts.Path,
+		})
+		return err
+	}
+
+	d.logger.Debug("project root added", map[string]interface{}{
+		"path":  opts.Path,
+		"label": label,
+	})
+
+	return nil
+}
+
+// UpdateProjectRoot changes the label and link handling of an existing project root.
+// Changing whether links are followed causes the root to be rescanned.
+func (d *Driver) UpdateProjectRoot(opts UpdateProjectRootOpts) error {
+	if err := d.updateProjectRoots(func(roots []types.ProjectRootConfig) ([]types.ProjectRootConfig, error) {
+		index := findProjectRoot(roots, opts.Path)
+		if index == -1 {
+			return nil, fmt.Errorf("project root %s is not configured", opts.Path)
+		}
+
+		roots[index].Label = opts.Label
+		roots[index].FollowSymlinks = opts.FollowSymlinks
+		return roots, nil
+	}); err != nil {
+		d.logger.Error("failed to update project root", map[string]interface{}{
+			"error": err.Error(),
+			"path":  opts.Path,
+		})
+		return err
+	}
+
+	return nil
+}
+
+// RemoveProjectRoot stops watching a project root. Projects on disk are left untouched.
+func (d *Driver) RemoveProjectRoot(opts RemoveProjectRootOpts) error {
+	if err := d.updateProjectRoots(func(roots []types.ProjectRootConfig) ([]types.ProjectRootConfig, error) {
+		index := findProjectRoot(roots, opts.Path)
+		if index == -1 {
+			return nil, fmt.Errorf("project root %s is not configured", opts.Path)
+		}
+
+		return append(roots[:index], roots[index+1:]...), nil
+	}); err != nil {
+		d.logger.Error("failed to remove project root", map[string]interface{}{
+			"error": err.Error(),
+			"path":  opts.Path,
+		})
+		return err
+	}
+
+	d.logger.Debug("project root removed", map[string]interface{}{
+		"path": opts.Path,
+	})
+
+	return nil
+}
+
+// ReorderProjectRoots sets the order of the project roots. The first root is used as the default for new projects.
+func (d *Driver) ReorderProjectRoots(opts ReorderProjectRootsOpts) error {
+	if err := d.updateProjectRoots(func(roots []types.ProjectRootConfig) ([]types.ProjectRootConfig, error) {
+		if len(opts.Paths) != len(roots) {
+			return nil, errors.New("reorder must include every project root exactly once")
+		}
+
+		reordered := make([]types.ProjectRootConfig, 0, len(roots))
+		for _, path := range opts.Paths {
+			index := findProjectRoot(roots, path)
+			if index == -1 {
+				return nil, fmt.Errorf("project root %s is not configured", path)
+			}
+
+			if findProjectRoot(reordered, path) != -1 {
+				return nil, fmt.Errorf("project root %s is listed more than once", path)
+			}
+
+			reordered = append(reordered, roots[index])
+		}
+
+		return reordered, nil
+	}); err != nil {
+		d.logger.Error("failed to reorder project roots", map[string]interface{}{
+			"error": err.Error(),
+			"paths": opts.Paths,
+		})
+		return err
+	}
+
+	return nil
+}
+
 func (d *Driver) GetDetails() (*Details, error) {
 	aConfigPath, _, err := d.getApplicationConfig()
 	if err != nil {
@@ -109,3 +296,54 @@ func (d *Driver) getRocketBlendConfig() (string, *rbtypes.Config, error) {
 
 	return d.rbConfigurator.Path(), config, nil
 }
+
+func (d *Driver) updateProjectRoots(updateFunc func(roots []types.ProjectRootConfig) ([]types.ProjectRootConfig, error)) error {
+	config, err := d.configurator.Get()
+	if err != nil {
+		return err
+	}
+
+	roots, err := updateFunc(config.Project.Roots)
+	if err != nil {
+		return err
+	}
+
+	if err := validateProjectRoots(roots); err != nil {
+		return err
+	}
+
+	config.Project.Roots = roots
+	if err := d.configurator.Save(config); err != nil {
+		return err
+	}
+
+	if err := d.portfolio.Refresh(d.ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateProjectRoots ensures no two roots overlap, as the watcher refuses to watch nested paths.
+func validateProjectRoots(roots []types.ProjectRootConfig) error {
+	paths := make([]string, 0, len(roots))
+	for _, root := range roots {
+		paths = append(paths, root.Path)
+	}
+
+	if err := watcher.ValidatePaths(paths...); err != nil {
+		return fmt.Errorf("invalid project roots: %w", err)
+	}
+
+	return nil
+}
+
+func findProjectRoot(roots []types.ProjectRootConfig, path string) int {
+	for i, root := range roots {
+		if filepath.Clean(root.Path) == filepath.Clean(path) {
+			return i
+		}
+	}
+
+	return -1
+}
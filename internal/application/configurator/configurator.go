@@ -161,5 +161,33 @@ func load(path string, name string, extension string) (*viper.Viper, error) {
 		return nil, err
 	}
 
+	migrateProjectPaths(v)
+
 	return v, nil
 }
+
+// migrateProjectPaths converts the legacy list of project paths into labelled project roots.
+func migrateProjectPaths(v *viper.Viper) {
+	if v.IsSet("project.roots") || !v.IsSet("project.paths") {
+		return
+	}
+
+	paths := v.GetStringSlice("project.paths")
+	roots := make([]map[string]interface{}, 0, len(paths))
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+
+		roots = append(roots, map[string]interface{}{
+			"path":  path,
+			"label": filepath.Base(path),
+		})
+	}
+
+	v.MergeConfigMap(map[string]interface{}{
+		"project": map[string]interface{}{
+			"roots": roots,
+		},
+	})
+}
@@ -2,6 +2,7 @@ package container
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/rocketblend/rocketblend-desktop/internal/application/configurator"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/operator"
@@ -153,6 +154,9 @@ func (c *Container) GetPortfolio() (types.Portfolio, error) {
 			project.WithRocketBlendRepository(rbRepository),
 			project.WithRocketBlendDriver(rbDriver),
 			project.WithBlender(blender),
+			project.WithTemplatePath(filepath.Join(c.applicationDir, project.TemplateDirName)),
+			project.WithThumbnailCachePath(filepath.Join(c.applicationDir, "cache", project.ThumbnailCacheDirName)),
+			project.WithUserStatePath(filepath.Join(c.applicationDir, project.UserStateFileName)),
 			project.WithWatcherDebounceDuration(c.watcherDebounce),
 		)
 	})
@@ -21,6 +21,7 @@ type (
 		dispatcher types.Dispatcher
 		tracker    types.Tracker
 		operator   types.Operator
+		store      types.Store
 
 		portfolio types.Portfolio
 		catalog   types.Catalog
@@ -47,6 +48,7 @@ type (
 		dispatcher types.Dispatcher
 		tracker    types.Tracker
 		operator   types.Operator
+		store      types.Store
 
 		portfolio types.Portfolio
 		catalog   types.Catalog
@@ -116,6 +118,7 @@ func NewDriver(opts ...Option) (*Driver, error) {
 		dispatcher:        dependencies.dispatcher,
 		tracker:           dependencies.tracker,
 		operator:          dependencies.operator,
+		store:             dependencies.store,
 		portfolio:         dependencies.portfolio,
 		catalog:           dependencies.catalog,
 		configurator:      dependencies.configurator,
@@ -154,6 +157,11 @@ func setupDependencies(container types.Container) (*dependecies, error) {
 		return nil, err
 	}
 
+	store, err := container.GetStore()
+	if err != nil {
+		return nil, err
+	}
+
 	configurator, err := container.GetConfigurator()
 	if err != nil {
 		return nil, err
@@ -180,6 +188,7 @@ func setupDependencies(container types.Container) (*dependecies, error) {
 		dispatcher:     dispatcher,
 		tracker:        tracker,
 		operator:       operator,
+		store:          store,
 		configurator:   configurator,
 		rbConfigurator: rbConfigurator,
 		portfolio:      portfolio,
@@ -0,0 +1,16 @@
+package enums
+
+type ArchiveFormat string
+
+const (
+	ArchiveFormatZip    ArchiveFormat = "zip"
+	ArchiveFormatTarZst ArchiveFormat = "tar.zst"
+)
+
+var ArchiveFormats = []struct {
+	Value  ArchiveFormat
+	TSName string
+}{
+	{ArchiveFormatZip, "ZIP"},
+	{ArchiveFormatTarZst, "TARZST"},
+}
@@ -0,0 +1,18 @@
+package enums
+
+type ProjectSort string
+
+const (
+	ProjectSortName       ProjectSort = "name"
+	ProjectSortUpdated    ProjectSort = "updated"
+	ProjectSortLastOpened ProjectSort = "last-opened"
+)
+
+var ProjectSorts = []struct {
+	Value  ProjectSort
+	TSName string
+}{
+	{ProjectSortName, "NAME"},
+	{ProjectSortUpdated, "UPDATED"},
+	{ProjectSortLastOpened, "LAST_OPENED"},
+}
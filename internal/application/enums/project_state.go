@@ -0,0 +1,18 @@
+package enums
+
+type ProjectState string
+
+const (
+	ProjectStateReady       ProjectState = "ready"
+	ProjectStateBrokenLinks ProjectState = "broken-links"
+	ProjectStateConflict    ProjectState = "conflict"
+)
+
+var ProjectStates = []struct {
+	Value  ProjectState
+	TSName string
+}{
+	{ProjectStateReady, "READY"},
+	{ProjectStateBrokenLinks, "BROKEN_LINKS"},
+	{ProjectStateConflict, "CONFLICT"},
+}
@@ -41,6 +41,10 @@ func (d *Driver) setupDriverEventHandlers() error {
 		return err
 	}
 
+	if err := d.subscribeToEvent(events.StoreBatchChannel, d.handleStoreBatchEvent); err != nil {
+		return err
+	}
+
 	if err := d.subscribeToEvent(events.ProjectRunChannel, d.handleProjectRunEvent); err != nil {
 		return err
 	}
@@ -104,6 +108,16 @@ func (d *Driver) handleStoreRemoveEvent(e types.Eventer) error {
 	return nil
 }
 
+func (d *Driver) handleStoreBatchEvent(e types.Eventer) error {
+	ev, ok := e.(*events.StoreBatchEvent)
+	if !ok {
+		return errors.New("invalid event type")
+	}
+
+	runtime.EventsEmit(d.ctx, events.StoreBatchChannel, ev)
+	return nil
+}
+
 func (d *Driver) setupRuntimeEventHandlers() error {
 	if err := d.ctx.Err(); err != nil {
 		return err
@@ -3,18 +3,28 @@ package events
 import (
 	"github.com/google/uuid"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
 )
 
 const (
 	StoreInsertChannel = "store.insert"
 	StoreRemoveChannel = "store.remove"
+	StoreBatchChannel  = "store.batch"
 )
 
 type (
 	StoreEvent struct {
 		Event
+		types.StoreChange
+	}
+
+	// StoreBatchEvent is emitted once for a batch of writes, in place of an event per index.
+	StoreBatchEvent struct {
+		Event
 
-		ID        uuid.UUID           `json:"id"`
-		IndexType indextype.IndexType `json:"indexType"`
+		Revision   uint64                `json:"revision"` // Store revision after the batch.
+		Inserted   []uuid.UUID           `json:"inserted,omitempty"`
+		Removed    []uuid.UUID           `json:"removed,omitempty"`
+		IndexTypes []indextype.IndexType `json:"indexTypes"`
 	}
 )
@@ -112,7 +112,7 @@ func (h *Handler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	if len(result) == 0 {
+	if len(result.Indexes) == 0 {
 		h.respondWithError(res, http.StatusNotFound, "Resource not found", nil)
 		return
 	}
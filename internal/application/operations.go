@@ -70,6 +70,18 @@ func (d *Driver) CancelOperation(opts CancelOperationOpts) error {
 	return nil
 }
 
+// operationProgress returns a progress func that records progress against the given operation.
+func (d *Driver) operationProgress(ctx context.Context, opid uuid.UUID) types.ProgressFunc {
+	return func(progress *types.Progress) {
+		if err := d.operator.Progress(ctx, opid, progress); err != nil {
+			d.logger.Warn("failed to update operation progress", map[string]interface{}{
+				"error": err.Error(),
+				"opid":  opid,
+			})
+		}
+	}
+}
+
 func (d *Driver) LongRunningOperation() (uuid.UUID, error) {
 	opid, err := d.operator.Create(d.ctx, func(ctx context.Context, opid uuid.UUID) (interface{}, error) {
 		// Simulate a long-running operation
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/flowshot-io/x/pkg/logger"
 	"github.com/google/uuid"
@@ -14,10 +15,19 @@ import (
 	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
 )
 
+var (
+	errOperationCancelled = errors.New("operation cancelled")
+	errOperationCompleted = errors.New("operation already completed")
+)
+
 type (
 	operation struct {
 		ctx    context.Context
 		cancel context.CancelFunc
+
+		// mu serialises writes to the stored operation, so a finished operation is never overwritten.
+		mu    sync.Mutex
+		state types.Operation
 	}
 
 	Operator struct {
@@ -87,17 +97,17 @@ func (o *Operator) Create(ctx context.Context, opFunc func(ctx context.Context,
 	opid := uuid.New()
 	opctx, cancel := context.WithCancel(ctx)
 
+	op := &operation{
+		ctx:    opctx,
+		cancel: cancel,
+		state:  types.Operation{ID: opid},
+	}
+
 	o.operationsMux.Lock()
-	o.operations[opid] = &operation{ctx: opctx, cancel: cancel}
+	o.operations[opid] = op
 	o.operationsMux.Unlock()
 
-	operation := types.Operation{ID: opid}
-	index, err := convertToSearchIndex(operation)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if err := o.store.Insert(ctx, index); err != nil {
+	if err := o.update(ctx, op, func(*types.Operation) {}); err != nil {
 		return uuid.Nil, err
 	}
 
@@ -106,32 +116,25 @@ func (o *Operator) Create(ctx context.Context, opFunc func(ctx context.Context,
 	go func() {
 		defer cancel()
 		result, err := opFunc(opctx, opid)
-
-		operation := types.Operation{
-			ID:        opid,
-			Completed: true,
-			Result:    result,
-		}
-
 		if err != nil {
-			operation.ErrorMsg = err.Error()
 			o.logger.Error("operation failed", map[string]interface{}{"error": err.Error()})
 		}
 
 		// TODO: Handle failure better. We still want to update the operation state.
-		index, err := convertToSearchIndex(operation)
-		if err != nil {
-			o.logger.Error("failed to marshal Operation", map[string]interface{}{"error": err.Error()})
-			return
-		}
-
-		if err := o.store.Insert(context.Background(), index); err != nil {
-			o.logger.Error("failed to insert Operation", map[string]interface{}{"error": err.Error()})
+		if uerr := o.update(context.Background(), op, func(state *types.Operation) {
+			state.Completed = true
+			state.Result = result
+			if err != nil {
+				state.ErrorMsg = err.Error()
+			}
+		}); uerr != nil {
+			if !errors.Is(uerr, errOperationCompleted) {
+				o.logger.Error("failed to insert Operation", map[string]interface{}{"error": uerr.Error()})
+			}
 			return
 		}
 
 		o.logger.Info("operation ended", map[string]interface{}{"id": opid})
-
 	}()
 
 	return opid, nil
@@ -153,13 +156,13 @@ func (o *Operator) Get(ctx context.Context, opid uuid.UUID) (*types.Operation, e
 
 func (o *Operator) List(ctx context.Context, opts ...listoption.ListOption) ([]*types.Operation, error) {
 	opts = append(opts, listoption.WithType(indextype.Operation))
-	indexes, err := o.store.List(ctx, opts...)
+	result, err := o.store.List(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	operations := make([]*types.Operation, 0, len(indexes))
-	for _, index := range indexes {
+	operations := make([]*types.Operation, 0, len(result.Indexes))
+	for _, index := range result.Indexes {
 		op, err := convertIndexToOperation(index)
 		if err != nil {
 			return nil, err
@@ -176,6 +179,29 @@ func (o *Operator) List(ctx context.Context, opts ...listoption.ListOption) ([]*
 	return operations, nil
 }
 
+// Progress updates the progress of a running operation.
+func (o *Operator) Progress(ctx context.Context, opid uuid.UUID, progress *types.Progress) error {
+	o.operationsMux.RLock()
+	op, exists := o.operations[opid]
+	o.operationsMux.RUnlock()
+
+	if !exists {
+		return errors.New("operation does not exist")
+	}
+
+	if op.ctx.Err() != nil {
+		return op.ctx.Err()
+	}
+
+	if err := o.update(ctx, op, func(state *types.Operation) {
+		state.Progress = progress
+	}); err != nil && !errors.Is(err, errOperationCompleted) {
+		return err
+	}
+
+	return nil
+}
+
 func (o *Operator) Cancel(opid uuid.UUID) error {
 	o.operationsMux.RLock()
 	op, exists := o.operations[opid]
@@ -188,18 +214,39 @@ func (o *Operator) Cancel(opid uuid.UUID) error {
 	op.cancel()
 	o.logger.Info("cancelled operation", map[string]interface{}{"id": opid})
 
-	operation := types.Operation{
-		ID:        opid,
-		ErrorMsg:  errors.New("operation cancelled").Error(),
-		Completed: true,
+	if err := o.update(context.Background(), op, func(state *types.Operation) {
+		state.Completed = true
+		state.ErrorMsg = errOperationCancelled.Error()
+	}); err != nil && !errors.Is(err, errOperationCompleted) {
+		return err
 	}
 
-	index, err := convertToSearchIndex(operation)
+	return nil
+}
+
+// update applies a change to the operation's state and stores it. Operations that have already completed, or been
+// cancelled, are left as they are.
+func (o *Operator) update(ctx context.Context, op *operation, apply func(state *types.Operation)) error {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	if op.state.Completed {
+		return errOperationCompleted
+	}
+
+	state := op.state
+	apply(&state)
+
+	index, err := convertToSearchIndex(state)
 	if err != nil {
 		return err
 	}
 
-	o.store.Insert(context.Background(), index)
+	if err := o.store.Insert(ctx, index); err != nil {
+		return err
+	}
+
+	op.state = state
 	return nil
 }
 
@@ -224,10 +271,12 @@ func convertToSearchIndex(operation types.Operation) (*types.Index, error) {
 		state = "completed"
 	}
 
+	// Dated by their last update, so finished operations expire from the store.
 	return &types.Index{
 		ID:    operation.ID,
 		Type:  indextype.Operation,
 		State: state,
+		Date:  time.Now(),
 		Data:  string(data),
 	}, nil
 }
@@ -2,11 +2,14 @@ package application
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/enums"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/store/listoption"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	"github.com/rocketblend/rocketblend/pkg/reference"
 )
 
 type (
@@ -23,10 +26,25 @@ type (
 		References []string           `json:"references,omitempty"`
 		Type       enums.PackageType  `json:"type"`
 		State      enums.PackageState `json:"state"`
+		Highlight  bool               `json:"highlight,omitempty"`
+		Size       int                `json:"size,omitempty"`
+		From       int                `json:"from,omitempty"`
 	}
 
 	ListPackagesResult struct {
-		Packages []*types.Package `json:"packages,omitempty"`
+		Packages  []*types.Package                  `json:"packages,omitempty"`
+		Total     uint64                            `json:"total"`
+		Took      time.Duration                     `json:"took"`
+		Facets    map[string]*types.Facet           `json:"facets,omitempty"`
+		Fragments map[uuid.UUID]map[string][]string `json:"fragments,omitempty"`
+	}
+
+	SuggestPackagesOpts struct {
+		Prefix string `json:"prefix"`
+	}
+
+	SuggestPackagesResult struct {
+		Suggestions []*types.Suggestion `json:"suggestions"`
 	}
 
 	InstallPackageOpts struct {
@@ -38,12 +56,47 @@ type (
 	}
 
 	AddPackageOpts struct {
-		Reference string `json:"reference"`
+		Reference  string            `json:"reference"`
+		Definition *types.Definition `json:"definition,omitempty"`
+		Update     bool              `json:"update"`
+	}
+
+	// AddPackageResult lists the problems with the definition when it couldn't be added.
+	AddPackageResult struct {
+		Errors []*types.FieldError `json:"errors,omitempty"`
+	}
+
+	GetPackageDefinitionOpts struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	GetPackageDefinitionResult struct {
+		Reference  string            `json:"reference"`
+		Verified   bool              `json:"verified"`
+		Definition *types.Definition `json:"definition"`
+	}
+
+	UpdatePackageDefinitionOpts struct {
+		ID         uuid.UUID         `json:"id"`
+		Definition *types.Definition `json:"definition"`
+	}
+
+	// UpdatePackageDefinitionResult lists the problems with the definition when it couldn't be saved.
+	UpdatePackageDefinitionResult struct {
+		Errors []*types.FieldError `json:"errors,omitempty"`
+	}
+
+	DeletePackageDefinitionOpts struct {
+		ID uuid.UUID `json:"id"`
 	}
 
 	UninstallPackageOpts struct {
 		ID uuid.UUID `json:"id"`
 	}
+
+	RefreshPackagesResult struct {
+		OperationID uuid.UUID `json:"operationID"`
+	}
 )
 
 func (d *Driver) GetPackage(opts GetPackageOpts) (*GetPackageResult, error) {
@@ -72,12 +125,27 @@ func (d *Driver) ListPackages(opts ListPackagesOpts) (*ListPackagesResult, error
 		"refs":  opts.References,
 	})
 
-	response, err := d.catalog.ListPackages(ctx, []listoption.ListOption{
+	listOpts := []listoption.ListOption{
 		listoption.WithQuery(opts.Query),
 		listoption.WithReferences(opts.References...),
 		listoption.WithCategory(string(opts.Type)),
 		listoption.WithState(string(opts.State)),
-	}...)
+		listoption.WithFacets("category", "state"),
+	}
+
+	if opts.Highlight {
+		listOpts = append(listOpts, listoption.WithHighlight())
+	}
+
+	if opts.Size > 0 {
+		listOpts = append(listOpts, listoption.WithSize(opts.Size))
+	}
+
+	if opts.From > 0 {
+		listOpts = append(listOpts, listoption.WithFrom(opts.From))
+	}
+
+	response, err := d.catalog.ListPackages(ctx, listOpts...)
 	if err != nil {
 		d.logger.Error("failed to find all packages", map[string]interface{}{"error": err.Error()})
 		return nil, err
@@ -86,21 +154,113 @@ func (d *Driver) ListPackages(opts ListPackagesOpts) (*ListPackagesResult, error
 	d.logger.Debug("found packages", map[string]interface{}{"packages": len(response.Packages)})
 
 	return &ListPackagesResult{
-		Packages: response.Packages,
+		Packages:  response.Packages,
+		Total:     response.Total,
+		Took:      response.Took,
+		Facets:    response.Facets,
+		Fragments: response.Fragments,
+	}, nil
+}
+
+func (d *Driver) SuggestPackages(opts SuggestPackagesOpts) (*SuggestPackagesResult, error) {
+	response, err := d.catalog.SuggestPackages(d.ctx, opts.Prefix)
+	if err != nil {
+		d.logger.Error("failed to suggest packages", map[string]interface{}{
+			"error":  err.Error(),
+			"prefix": opts.Prefix,
+		})
+		return nil, err
+	}
+
+	return &SuggestPackagesResult{
+		Suggestions: response.Suggestions,
 	}, nil
 }
 
-func (d *Driver) AddPackage(opts AddPackageOpts) error {
-	// ref, err := reference.Parse(opts.Reference)
-	// if err != nil {
-	// 	d.logger.Error("failed to parse reference", map[string]interface{}{"error": err.Error()})
-	// 	return err
-	// }
+// AddPackage adds a package from its reference, or from a custom definition. Problems with the definition are
+// returned in the result rather than as an error.
+func (d *Driver) AddPackage(opts AddPackageOpts) (*AddPackageResult, error) {
+	ref, err := reference.Parse(opts.Reference)
+	if err != nil {
+		d.logger.Error("failed to parse reference", map[string]interface{}{"error": err.Error()})
+		return nil, err
+	}
 
-	// if err := d.catalog.AddPackage(d.ctx, ref); err != nil {
-	// 	d.logger.Error("failed to add package", map[string]interface{}{"error": err.Error()})
-	// 	return err
-	// }
+	if err := d.catalog.AddPackage(d.ctx, &types.AddPackageOpts{
+		Reference:  ref,
+		Definition: opts.Definition,
+		Update:     opts.Update,
+	}); err != nil {
+		var validation *types.ValidationError
+		if errors.As(err, &validation) {
+			return &AddPackageResult{
+				Errors: validation.Errors,
+			}, nil
+		}
+
+		d.logger.Error("failed to add package", map[string]interface{}{
+			"error":     err.Error(),
+			"reference": ref,
+		})
+		return nil, err
+	}
+
+	return &AddPackageResult{}, nil
+}
+
+func (d *Driver) GetPackageDefinition(opts GetPackageDefinitionOpts) (*GetPackageDefinitionResult, error) {
+	result, err := d.catalog.GetPackageDefinition(d.ctx, &types.GetPackageDefinitionOpts{
+		ID: opts.ID,
+	})
+	if err != nil {
+		d.logger.Error("failed to get package definition", map[string]interface{}{
+			"error": err.Error(),
+			"id":    opts.ID,
+		})
+		return nil, err
+	}
+
+	return &GetPackageDefinitionResult{
+		Reference:  result.Reference.String(),
+		Verified:   result.Verified,
+		Definition: result.Definition,
+	}, nil
+}
+
+// UpdatePackageDefinition saves changes to a custom package definition. Problems with the definition are returned
+// in the result rather than as an error.
+func (d *Driver) UpdatePackageDefinition(opts UpdatePackageDefinitionOpts) (*UpdatePackageDefinitionResult, error) {
+	if err := d.catalog.UpdatePackageDefinition(d.ctx, &types.UpdatePackageDefinitionOpts{
+		ID:         opts.ID,
+		Definition: opts.Definition,
+	}); err != nil {
+		var validation *types.ValidationError
+		if errors.As(err, &validation) {
+			return &UpdatePackageDefinitionResult{
+				Errors: validation.Errors,
+			}, nil
+		}
+
+		d.logger.Error("failed to update package definition", map[string]interface{}{
+			"error": err.Error(),
+			"id":    opts.ID,
+		})
+		return nil, err
+	}
+
+	return &UpdatePackageDefinitionResult{}, nil
+}
+
+func (d *Driver) DeletePackageDefinition(opts DeletePackageDefinitionOpts) error {
+	if err := d.catalog.DeletePackageDefinition(d.ctx, &types.DeletePackageDefinitionOpts{
+		ID: opts.ID,
+	}); err != nil {
+		d.logger.Error("failed to delete package definition", map[string]interface{}{
+			"error": err.Error(),
+			"id":    opts.ID,
+		})
+		return err
+	}
 
 	return nil
 }
@@ -157,13 +317,28 @@ func (d *Driver) InstallPackage(opts InstallPackageOpts) (*InstallPackageResult,
 	}, nil
 }
 
-func (d *Driver) RefreshPackages() error {
-	if err := d.catalog.RefreshPackages(d.ctx); err != nil {
-		d.logger.Error("failed to refresh packages", map[string]interface{}{"error": err.Error()})
-		return err
+// RefreshPackages pulls the latest definitions from every known package repository as an operation. The operation's
+// result lists the outcome for each repository.
+func (d *Driver) RefreshPackages() (*RefreshPackagesResult, error) {
+	opid, err := d.operator.Create(d.ctx, func(ctx context.Context, opid uuid.UUID) (interface{}, error) {
+		result, err := d.catalog.RefreshPackages(ctx)
+		if err != nil {
+			d.logger.Error("failed to refresh packages", map[string]interface{}{
+				"error": err.Error(),
+				"opid":  opid,
+			})
+			return nil, err
+		}
+
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &RefreshPackagesResult{
+		OperationID: opid,
+	}, nil
 }
 
 func (d *Driver) UninstallPackage(opts UninstallPackageOpts) error {
@@ -189,7 +364,7 @@ func (d *Driver) autoRefreshPackages(ctx context.Context) error {
 		return nil
 	}
 
-	if err := d.catalog.RefreshPackages(ctx); err != nil {
+	if _, err := d.catalog.RefreshPackages(ctx); err != nil {
 		return err
 	}
 
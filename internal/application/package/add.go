@@ -3,10 +3,126 @@ package pack
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	rbhelpers "github.com/rocketblend/rocketblend/pkg/helpers"
+	"github.com/rocketblend/rocketblend/pkg/reference"
+	rbtypes "github.com/rocketblend/rocketblend/pkg/types"
 )
 
+var (
+	ErrPackageExists   = errors.New("package already exists")
+	ErrPackageVerified = errors.New("verified packages can't be changed")
+)
+
+// AddPackage adds a package to the packages path, where the package watcher picks it up. Without a definition, the
+// definition is fetched from the reference's repository. Otherwise the given definition is validated and saved under
+// the reference. Existing packages are only overwritten when Update is set.
 func (r *Repository) AddPackage(ctx context.Context, opts *types.AddPackageOpts) error {
-	return errors.New("not implemented")
+	if err := validateReference(opts.Reference); err != nil {
+		return err
+	}
+
+	definitionPath, err := r.definitionPath(opts.Reference)
+	if err != nil {
+		return err
+	}
+
+	exists, err := fileExists(definitionPath)
+	if err != nil {
+		return err
+	}
+
+	if exists && !opts.Update {
+		return fmt.Errorf("%w: %s", ErrPackageExists, opts.Reference)
+	}
+
+	if opts.Definition == nil {
+		return r.fetchDefinition(ctx, opts.Reference, opts.Update)
+	}
+
+	if isPackageVerified(opts.Reference) {
+		return fmt.Errorf("%w: %s", ErrPackageVerified, opts.Reference)
+	}
+
+	if err := r.validateDefinition(opts.Definition); err != nil {
+		return err
+	}
+
+	if err := rbhelpers.Save(r.validator, definitionPath, opts.Definition, true, opts.Update); err != nil {
+		return fmt.Errorf("failed to save package definition: %w", err)
+	}
+
+	r.logger.Info("package definition saved", map[string]interface{}{
+		"reference": opts.Reference,
+		"path":      definitionPath,
+		"update":    exists,
+	})
+
+	return nil
+}
+
+// fetchDefinition downloads the definition for the reference into the packages path.
+func (r *Repository) fetchDefinition(ctx context.Context, ref reference.Reference, update bool) error {
+	result, err := r.rbRepository.GetPackages(ctx, &rbtypes.GetPackagesOpts{
+		References: []reference.Reference{ref},
+		Update:     update,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get package definition: %w", err)
+	}
+
+	if _, ok := result.Packs[ref]; !ok {
+		return fmt.Errorf("package not found: %s", ref)
+	}
+
+	return nil
+}
+
+// definitionPath returns the path of the definition file for the reference within the packages path.
+func (r *Repository) definitionPath(ref reference.Reference) (string, error) {
+	config, err := r.rbConfigurator.Get()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(config.PackagesPath, filepath.FromSlash(ref.String()), types.PackageFileName), nil
+}
+
+// validateReference checks the reference can be used as a path within the packages path.
+func validateReference(ref reference.Reference) error {
+	value := ref.String()
+	if value == "" {
+		return errors.New("package reference is required")
+	}
+
+	if path.IsAbs(value) || strings.Contains(value, "\\") || path.Clean(value) != value {
+		return fmt.Errorf("invalid package reference: %s", value)
+	}
+
+	for _, segment := range strings.Split(value, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("invalid package reference: %s", value)
+		}
+	}
+
+	return nil
+}
+
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	return false, err
 }
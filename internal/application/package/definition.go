@@ -0,0 +1,166 @@
+package pack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/enums"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	rbhelpers "github.com/rocketblend/rocketblend/pkg/helpers"
+	rbtypes "github.com/rocketblend/rocketblend/pkg/types"
+)
+
+var ErrPackageInstalled = errors.New("package is installed")
+
+func (r *Repository) GetPackageDefinition(ctx context.Context, opts *types.GetPackageDefinitionOpts) (*types.GetPackageDefinitionResponse, error) {
+	pack, err := r.get(ctx, opts.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, err := rbhelpers.Load[types.Definition](r.validator, pack.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package definition: %w", err)
+	}
+
+	return &types.GetPackageDefinitionResponse{
+		Reference:  pack.Reference,
+		Verified:   pack.Verified,
+		Definition: definition,
+	}, nil
+}
+
+// UpdatePackageDefinition overwrites the definition of a custom package. Verified packages can't be changed.
+func (r *Repository) UpdatePackageDefinition(ctx context.Context, opts *types.UpdatePackageDefinitionOpts) error {
+	pack, err := r.get(ctx, opts.ID)
+	if err != nil {
+		return err
+	}
+
+	if pack.Verified {
+		return fmt.Errorf("%w: %s", ErrPackageVerified, pack.Reference)
+	}
+
+	if err := r.validateDefinition(opts.Definition); err != nil {
+		return err
+	}
+
+	if err := rbhelpers.Save(r.validator, pack.Path, opts.Definition, false, true); err != nil {
+		return fmt.Errorf("failed to save package definition: %w", err)
+	}
+
+	r.logger.Info("package definition updated", map[string]interface{}{
+		"id":        pack.ID,
+		"reference": pack.Reference,
+	})
+
+	return nil
+}
+
+// DeletePackageDefinition removes the definition of a custom package. Verified and installed packages can't be removed.
+func (r *Repository) DeletePackageDefinition(ctx context.Context, opts *types.DeletePackageDefinitionOpts) error {
+	pack, err := r.get(ctx, opts.ID)
+	if err != nil {
+		return err
+	}
+
+	if pack.Verified {
+		return fmt.Errorf("%w: %s", ErrPackageVerified, pack.Reference)
+	}
+
+	if pack.State == enums.PackageStateInstalled || pack.State == enums.PackageStateDownloading {
+		return fmt.Errorf("%w, uninstall it first: %s", ErrPackageInstalled, pack.Reference)
+	}
+
+	if err := os.Remove(pack.Path); err != nil {
+		return fmt.Errorf("failed to remove package definition: %w", err)
+	}
+
+	// Tidy up the reference's folder if the definition was all it held.
+	if err := os.Remove(filepath.Dir(pack.Path)); err != nil && !os.IsExist(err) {
+		r.logger.Debug("package folder not removed", map[string]interface{}{
+			"error": err.Error(),
+			"path":  filepath.Dir(pack.Path),
+		})
+	}
+
+	r.logger.Info("package definition deleted", map[string]interface{}{
+		"id":        pack.ID,
+		"reference": pack.Reference,
+	})
+
+	return nil
+}
+
+// validateDefinition checks the definition against the rocketblend package schema. Problems are returned together
+// as a types.ValidationError, with paths matching the definition's JSON fields.
+func (r *Repository) validateDefinition(definition *types.Definition) error {
+	validation := &types.ValidationError{}
+	if definition == nil {
+		validation.Add("", "definition is required")
+		return validation
+	}
+
+	packageType := enums.PackageType(definition.Type)
+	switch packageType {
+	case enums.PackageTypeBuild, enums.PackageTypeAddon:
+	case "":
+		validation.Add("type", "is required")
+	default:
+		validation.Add("type", "must be %s or %s", enums.PackageTypeBuild, enums.PackageTypeAddon)
+	}
+
+	if len(definition.Sources) == 0 && packageType == enums.PackageTypeBuild {
+		validation.Add("sources", "builds require at least one source")
+	}
+
+	platforms := make(map[rbtypes.Platform]struct{}, len(definition.Sources))
+	for i, source := range definition.Sources {
+		field := fmt.Sprintf("sources[%d]", i)
+		if source == nil {
+			validation.Add(field, "is required")
+			continue
+		}
+
+		if source.Platform == "" {
+			validation.Add(field+".platform", "is required")
+		} else if _, ok := platforms[source.Platform]; ok {
+			validation.Add(field+".platform", "has more than one source")
+		}
+		platforms[source.Platform] = struct{}{}
+
+		if source.URI == nil {
+			validation.Add(field+".uri", "is required")
+		}
+
+		if source.Resource == "" && packageType == enums.PackageTypeBuild {
+			validation.Add(field+".resource", "is required for builds")
+		}
+	}
+
+	if err := validation.Err(); err != nil {
+		return err
+	}
+
+	// Anything else the rocketblend schema rejects is reported against the field it failed on, when known.
+	if err := r.validator.Validate(definition); err != nil {
+		var fieldErrors validator.ValidationErrors
+		if !errors.As(err, &fieldErrors) {
+			validation.Add("", "%s", err.Error())
+			return validation
+		}
+
+		for _, fieldError := range fieldErrors {
+			validation.Add(jsonFieldPath(reflect.TypeOf(definition), fieldError.Namespace()), "%s", validationMessage(fieldError))
+		}
+
+		return validation
+	}
+
+	return nil
+}
@@ -14,13 +14,13 @@ func (r *Repository) ListPackages(ctx context.Context, opts ...listoption.ListOp
 	}
 
 	opts = append(opts, listoption.WithType(indextype.Package))
-	indexes, err := r.store.List(ctx, opts...)
+	result, err := r.store.List(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	packs := make([]*types.Package, 0, len(indexes))
-	for _, index := range indexes {
+	packs := make([]*types.Package, 0, len(result.Indexes))
+	for _, index := range result.Indexes {
 		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
@@ -33,6 +33,21 @@ func (r *Repository) ListPackages(ctx context.Context, opts ...listoption.ListOp
 	}
 
 	return &types.ListPackagesResponse{
-		Packages: packs,
+		Packages:  packs,
+		Total:     result.Total,
+		Took:      result.Took,
+		Facets:    result.Facets,
+		Fragments: result.Fragments,
+	}, nil
+}
+
+func (r *Repository) SuggestPackages(ctx context.Context, prefix string) (*types.SuggestResponse, error) {
+	suggestions, err := r.store.Suggest(ctx, prefix, indextype.Package)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SuggestResponse{
+		Suggestions: suggestions,
 	}, nil
 }
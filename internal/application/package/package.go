@@ -122,6 +122,15 @@ func New(opts ...Option) (*Repository, error) {
 		return nil, err
 	}
 
+	r := &Repository{
+		logger:         options.Logger,
+		validator:      options.Validator,
+		rbRepository:   options.rbRepository,
+		rbConfigurator: options.rbConfigurator,
+		store:          options.Store,
+		dispatcher:     options.Dispatcher,
+	}
+
 	watcher, err := watcher.New(
 		watcher.WithLogger(options.Logger),
 		watcher.WithEventDebounceDuration(options.WatcherDebounceDuration),
@@ -130,63 +139,94 @@ func New(opts ...Option) (*Repository, error) {
 			return filepath.Base(path) == types.PackageFileName
 		}),
 		watcher.WithUpdateObjectFunc(func(path string) error {
-			pack, err := load(options.rbConfigurator, options.Validator, path)
-			if err != nil {
-				return fmt.Errorf("failed to load package %s: %w", path, err)
-			}
+			return r.index(context.Background(), path)
+		}),
+		watcher.WithUpdateObjectsFunc(func(paths []string) error {
+			return r.indexBatch(context.Background(), paths)
+		}),
+		watcher.WithRemoveObjectFunc(func(removePath string) error {
+			return r.remove(context.Background(), removePath)
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
 
-			// TODO: This is a workaround to avoid losing operations on package update. We need to find a better solution once we redo operations.
-			existingIndex, err := options.Store.Get(context.Background(), pack.ID)
-			if err != nil && !errors.Is(err, store.ErrNotFound) {
-				return err
-			}
+	r.watcher = watcher
 
-			if existingIndex != nil {
-				existing, err := convertFromIndex(existingIndex)
-				if err != nil {
-					return err
-				}
+	return r, nil
+}
 
-				options.Logger.Debug("copying operations", map[string]interface{}{
-					"package":    pack,
-					"operations": existing.Operations,
-				})
+func (r *Repository) Close() error {
+	return r.watcher.Close()
+}
 
-				pack.Operations = existing.Operations
-			}
+func (r *Repository) index(ctx context.Context, packagePath string) error {
+	index, err := r.loadIndex(ctx, packagePath)
+	if err != nil {
+		return err
+	}
 
-			index, err := convertToIndex(pack)
-			if err != nil {
-				return err
-			}
+	return r.store.Insert(ctx, index)
+}
 
-			return options.Store.Insert(context.Background(), index)
-		}),
-		watcher.WithRemoveObjectFunc(func(removePath string) error {
-			if err := options.Store.RemoveByReference(context.Background(), path.Clean(removePath)); err != nil && !errors.Is(err, store.ErrNotFound) {
-				return err
+// indexBatch indexes the packages at the given paths in a single store write.
+// Packages that fail to load are removed from the store, matching the behaviour of a single update.
+func (r *Repository) indexBatch(ctx context.Context, packagePaths []string) error {
+	indexes := make([]*types.Index, 0, len(packagePaths))
+	for _, packagePath := range packagePaths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		index, err := r.loadIndex(ctx, packagePath)
+		if err != nil {
+			r.logger.Error("failed to index package", map[string]interface{}{
+				"error": err.Error(),
+				"path":  packagePath,
+			})
+
+			if err := r.remove(ctx, packagePath); err != nil {
+				r.logger.Error("failed to remove package", map[string]interface{}{
+					"error": err.Error(),
+					"path":  packagePath,
+				})
 			}
 
-			return nil
-		}),
-	)
+			continue
+		}
+
+		indexes = append(indexes, index)
+	}
+
+	return r.store.InsertBatch(ctx, indexes)
+}
+
+func (r *Repository) loadIndex(ctx context.Context, packagePath string) (*types.Index, error) {
+	pack, err := load(r.rbConfigurator, r.validator, packagePath)
 	if err != nil {
+		return nil, fmt.Errorf("failed to load package %s: %w", packagePath, err)
+	}
+
+	// Operations aren't saved with the package, so are carried over from the existing index.
+	existing, err := r.store.Get(ctx, pack.ID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
 		return nil, err
 	}
 
-	return &Repository{
-		logger:         options.Logger,
-		validator:      options.Validator,
-		rbRepository:   options.rbRepository,
-		rbConfigurator: options.rbConfigurator,
-		store:          options.Store,
-		dispatcher:     options.Dispatcher,
-		watcher:        watcher,
-	}, nil
+	if existing != nil {
+		pack.Operations = existing.Operations
+	}
+
+	return convertToIndex(pack)
 }
 
-func (r *Repository) Close() error {
-	return r.watcher.Close()
+func (r *Repository) remove(ctx context.Context, removePath string) error {
+	if err := r.store.RemoveByReference(ctx, path.Clean(removePath)); err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	return nil
 }
 
 func convertFromIndex(index *types.Index) (*types.Package, error) {
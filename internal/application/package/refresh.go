@@ -2,25 +2,126 @@ package pack
 
 import (
 	"context"
+	"errors"
+	"sort"
 
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/listoption"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
 	"github.com/rocketblend/rocketblend/pkg/reference"
 	rbtypes "github.com/rocketblend/rocketblend/pkg/types"
 )
 
-// RefreshPackages refreshes the packages.
-// Currently, it only refreshes the default build repo.
-func (r *Repository) RefreshPackages(ctx context.Context) error {
+// RefreshPackages pulls the latest definitions from every known repository: those of the packages in the package
+// tree, those used by indexed projects, and the default build's. Each repository is pulled separately, so a failure
+// in one is recorded in its result without stopping the others. Cancelling stops before the next repository.
+func (r *Repository) RefreshPackages(ctx context.Context) (*types.RefreshPackagesResult, error) {
+	repositories, err := r.knownRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.RefreshPackagesResult{
+		Repositories: make([]*types.RepositoryRefresh, 0, len(repositories)),
+	}
+
+	names := make([]string, 0, len(repositories))
+	for name := range repositories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		refresh := &types.RepositoryRefresh{
+			Repository: name,
+			References: repositories[name],
+		}
+
+		if _, err := r.rbRepository.GetPackages(ctx, &rbtypes.GetPackagesOpts{
+			References: repositories[name],
+			Update:     true,
+		}); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return result, err
+			}
+
+			r.logger.Warn("failed to refresh package repository", map[string]interface{}{
+				"error":      err.Error(),
+				"repository": name,
+			})
+
+			refresh.Error = err.Error()
+		}
+
+		result.Repositories = append(result.Repositories, refresh)
+	}
+
+	r.logger.Info("refreshed package repositories", map[string]interface{}{
+		"repositories": len(result.Repositories),
+	})
+
+	return result, nil
+}
+
+// knownRepositories returns the references known locally, grouped by the repository they belong to.
+func (r *Repository) knownRepositories(ctx context.Context) (map[string][]reference.Reference, error) {
 	config, err := r.rbConfigurator.Get()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	references := []reference.Reference{config.DefaultBuild}
+
+	packages, err := r.store.List(ctx, listoption.WithType(indextype.Package), listoption.WithSize(10000))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range packages.Indexes {
+		pack, err := convertFromIndex(index)
+		if err != nil {
+			return nil, err
+		}
+
+		references = append(references, pack.Reference)
+	}
+
+	projects, err := r.store.List(ctx, listoption.WithType(indextype.Project), listoption.WithSize(10000))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range projects.Indexes {
+		for _, dependency := range index.Dependencies {
+			ref, err := reference.Parse(dependency)
+			if err != nil {
+				continue
+			}
+
+			references = append(references, ref)
+		}
 	}
 
-	if _, err := r.rbRepository.GetPackages(ctx, &rbtypes.GetPackagesOpts{
-		References: []reference.Reference{config.DefaultBuild},
-		Update:     true,
-	}); err != nil {
-		return err
+	seen := make(map[reference.Reference]struct{}, len(references))
+	repositories := make(map[string][]reference.Reference)
+	for _, ref := range references {
+		if _, ok := seen[ref]; ok {
+			continue
+		}
+		seen[ref] = struct{}{}
+
+		// References without a repository are local only, so have nothing to pull.
+		repository, err := ref.GetRepo()
+		if err != nil {
+			continue
+		}
+
+		repositories[repository] = append(repositories[repository], ref)
 	}
 
-	return nil
+	return repositories, nil
 }
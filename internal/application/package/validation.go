@@ -0,0 +1,130 @@
+package pack
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// jsonFieldPath converts a validator namespace, such as Definition.Package.Sources[0].URI, into the JSON path of the
+// field within the root type, such as sources[0].uri. Embedded structs are flattened, as they are in JSON.
+func jsonFieldPath(root reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")
+	if len(segments) > 0 {
+		segments = segments[1:] // The root type's name.
+	}
+
+	path := make([]string, 0, len(segments))
+	current := root
+	for _, segment := range segments {
+		name, indexes, _ := strings.Cut(segment, "[")
+		if indexes != "" {
+			indexes = "[" + indexes
+		}
+
+		current = derefType(current)
+		field, ok := structField(current, name)
+		if !ok {
+			// Unknown types, such as map values, keep the rest of the path as the validator gave it.
+			current = nil
+			path = append(path, segment)
+			continue
+		}
+
+		current = field.Type
+		if field.Anonymous {
+			continue
+		}
+
+		path = append(path, jsonFieldName(field)+indexes)
+
+		// Types with their own JSON encoding, such as versions, are a single field in JSON.
+		if marshalsItself(current) {
+			break
+		}
+
+		for i := strings.Count(indexes, "["); i > 0 && current != nil; i-- {
+			switch derefType(current).Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				current = derefType(current).Elem()
+			default:
+				current = nil
+			}
+		}
+	}
+
+	return strings.Join(path, ".")
+}
+
+func structField(t reflect.Type, name string) (reflect.StructField, bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+
+	if field, ok := t.FieldByName(name); ok {
+		return field, true
+	}
+
+	// Validators registered with a tag name function report JSON names instead of Go names.
+	for i := 0; i < t.NumField(); i++ {
+		if jsonFieldName(t.Field(i)) == name {
+			return t.Field(i), true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+
+	return name
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func marshalsItself(t reflect.Type) bool {
+	t = derefType(t)
+	if t == nil {
+		return false
+	}
+
+	pointer := reflect.PointerTo(t)
+	return pointer.Implements(jsonMarshalerType) || pointer.Implements(textMarshalerType)
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// validationMessage describes a failed validation rule in the same form as validateDefinition's own checks.
+func validationMessage(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", strings.ReplaceAll(fieldError.Param(), " ", ", "))
+	case "url", "uri":
+		return "must be a valid URL"
+	}
+
+	if fieldError.Param() != "" {
+		return fmt.Sprintf("failed %s=%s validation", fieldError.Tag(), fieldError.Param())
+	}
+
+	return fmt.Sprintf("failed %s validation", fieldError.Tag())
+}
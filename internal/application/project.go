@@ -3,9 +3,12 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/enums"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/store/listoption"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
 	"github.com/rocketblend/rocketblend-desktop/internal/helpers"
@@ -23,21 +26,85 @@ type (
 	}
 
 	ListProjectsOpts struct {
-		Query string `json:"query"`
+		Query      string            `json:"query"`
+		Sort       enums.ProjectSort `json:"sort,omitempty"`
+		PinnedOnly bool              `json:"pinnedOnly,omitempty"`
+		Tags       []string          `json:"tags,omitempty"`
+		Dependency string            `json:"dependency,omitempty"`
+		Highlight  bool              `json:"highlight,omitempty"`
+		Size       int               `json:"size,omitempty"`
+		From       int               `json:"from,omitempty"`
 	}
 
 	ListProjectsResult struct {
-		Projects []*types.Project `json:"projects"`
+		Projects  []*types.Project                  `json:"projects"`
+		Total     uint64                            `json:"total"`
+		Took      time.Duration                     `json:"took"`
+		Facets    map[string]*types.Facet           `json:"facets,omitempty"`
+		Fragments map[uuid.UUID]map[string][]string `json:"fragments,omitempty"`
+	}
+
+	SuggestProjectsOpts struct {
+		Prefix string `json:"prefix"`
+	}
+
+	SuggestProjectsResult struct {
+		Suggestions []*types.Suggestion `json:"suggestions"`
 	}
 
 	CreateProjectOpts struct {
-		Name string `json:"name"`
+		Name       string    `json:"name"`
+		Root       string    `json:"root,omitempty"`
+		TemplateID uuid.UUID `json:"templateID,omitempty"`
 	}
 
 	CreateProjectResult struct {
 		OperationID uuid.UUID `json:"operationID"`
 	}
 
+	DuplicateProjectOpts struct {
+		ID           uuid.UUID `json:"id"`
+		Name         string    `json:"name"`
+		Root         string    `json:"root,omitempty"`
+		ExcludeMedia bool      `json:"excludeMedia"`
+	}
+
+	DuplicateProjectResult struct {
+		OperationID uuid.UUID `json:"operationID"`
+	}
+
+	ExportProjectOpts struct {
+		ID                uuid.UUID           `json:"id"`
+		Path              string              `json:"path"`
+		Format            enums.ArchiveFormat `json:"format"`
+		ExcludeMedia      bool                `json:"excludeMedia"`
+		ExcludeRenders    bool                `json:"excludeRenders"`
+		HonourIgnoreFiles bool                `json:"honourIgnoreFiles"`
+	}
+
+	ExportProjectResult struct {
+		OperationID uuid.UUID `json:"operationID"`
+	}
+
+	ImportProjectOpts struct {
+		ArchivePath string `json:"archivePath"`
+		Root        string `json:"root,omitempty"`
+	}
+
+	ImportProjectResult struct {
+		OperationID uuid.UUID `json:"operationID"`
+	}
+
+	// ImportedProject is the result of an import operation. Missing packages are those listed in the
+	// archive's manifest that are not installed, so they can be offered for installation.
+	ImportedProject struct {
+		ID                uuid.UUID             `json:"id"`
+		Path              string                `json:"path"`
+		Regenerated       bool                  `json:"regenerated"`
+		MissingPackages   []*types.Package      `json:"missingPackages"`
+		UnknownReferences []reference.Reference `json:"unknownReferences"`
+	}
+
 	AddProjectPackageOpts struct {
 		ID        uuid.UUID           `json:"id"`
 		Reference reference.Reference `json:"reference"`
@@ -49,8 +116,9 @@ type (
 	}
 
 	UpdateProjectOpts struct {
-		ID   uuid.UUID `json:"id"`
-		Name *string   `json:"name,omitempty"`
+		ID       uuid.UUID `json:"id"`
+		Name     *string   `json:"name,omitempty"`
+		MainFile *string   `json:"mainFile,omitempty"`
 	}
 
 	DeleteProjectOpts struct {
@@ -58,12 +126,34 @@ type (
 	}
 
 	RunProjectOpts struct {
-		ID uuid.UUID `json:"id"`
+		ID       uuid.UUID `json:"id"`
+		FileName string    `json:"fileName,omitempty"`
 	}
 
 	RenderProjectOpts struct {
 		ID uuid.UUID `json:"id"`
 	}
+
+	PinProjectOpts struct {
+		ID     uuid.UUID `json:"id"`
+		Pinned bool      `json:"pinned"`
+	}
+
+	RegenerateProjectIDOpts struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	RegenerateProjectIDResult struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	GetProjectReferencesOpts struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	GetProjectReferencesResult struct {
+		References []*types.ProjectReference `json:"references"`
+	}
 )
 
 func (d *Driver) GetProject(opts GetPackageOpts) (*GetProjectResult, error) {
@@ -85,11 +175,50 @@ func (d *Driver) GetProject(opts GetPackageOpts) (*GetProjectResult, error) {
 func (d *Driver) ListProjects(opts ListProjectsOpts) (*ListProjectsResult, error) {
 	ctx := context.Background()
 
-	response, err := d.portfolio.ListProjects(ctx, listoption.WithQuery(opts.Query))
+	listOpts := []listoption.ListOption{
+		listoption.WithQuery(opts.Query),
+		listoption.WithFacets("tags", "dependencies", "state"),
+	}
+
+	switch opts.Sort {
+	case enums.ProjectSortName:
+		listOpts = append(listOpts, listoption.WithSort("name", false))
+	case enums.ProjectSortUpdated:
+		listOpts = append(listOpts, listoption.WithSort("date", true))
+	case enums.ProjectSortLastOpened:
+		listOpts = append(listOpts, listoption.WithSort("lastOpened", true))
+	}
+
+	if opts.PinnedOnly {
+		listOpts = append(listOpts, listoption.WithPinned())
+	}
+
+	if len(opts.Tags) > 0 {
+		listOpts = append(listOpts, listoption.WithTags(opts.Tags...))
+	}
+
+	if opts.Dependency != "" {
+		listOpts = append(listOpts, listoption.WithDependency(opts.Dependency))
+	}
+
+	if opts.Highlight {
+		listOpts = append(listOpts, listoption.WithHighlight())
+	}
+
+	if opts.Size > 0 {
+		listOpts = append(listOpts, listoption.WithSize(opts.Size))
+	}
+
+	if opts.From > 0 {
+		listOpts = append(listOpts, listoption.WithFrom(opts.From))
+	}
+
+	response, err := d.portfolio.ListProjects(ctx, listOpts...)
 	if err != nil {
 		d.logger.Error("failed to list projects", map[string]interface{}{
 			"error": err.Error(),
 			"query": opts.Query,
+			"sort":  opts.Sort,
 		})
 		return nil, err
 	}
@@ -99,12 +228,31 @@ func (d *Driver) ListProjects(opts ListProjectsOpts) (*ListProjectsResult, error
 	})
 
 	return &ListProjectsResult{
-		Projects: response.Projects,
+		Projects:  response.Projects,
+		Total:     response.Total,
+		Took:      response.Took,
+		Facets:    response.Facets,
+		Fragments: response.Fragments,
+	}, nil
+}
+
+func (d *Driver) SuggestProjects(opts SuggestProjectsOpts) (*SuggestProjectsResult, error) {
+	response, err := d.portfolio.SuggestProjects(d.ctx, opts.Prefix)
+	if err != nil {
+		d.logger.Error("failed to suggest projects", map[string]interface{}{
+			"error":  err.Error(),
+			"prefix": opts.Prefix,
+		})
+		return nil, err
+	}
+
+	return &SuggestProjectsResult{
+		Suggestions: response.Suggestions,
 	}, nil
 }
 
 func (d *Driver) CreateProject(opts CreateProjectOpts) (*CreateProjectResult, error) {
-	projectPath, err := d.getProjectPath()
+	projectPath, err := d.getProjectPath(opts.Root)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +269,7 @@ func (d *Driver) CreateProject(opts CreateProjectOpts) (*CreateProjectResult, er
 			BlendFileName: fileName + rbtypes.BlendFileExtension,
 			Path:          filepath.Join(projectPath, fileName),
 			Build:         defaultBuild,
+			TemplateID:    opts.TemplateID,
 		})
 		if err != nil {
 			d.logger.Error("failed to create project", map[string]interface{}{
@@ -146,6 +295,153 @@ func (d *Driver) CreateProject(opts CreateProjectOpts) (*CreateProjectResult, er
 	}, nil
 }
 
+func (d *Driver) DuplicateProject(opts DuplicateProjectOpts) (*DuplicateProjectResult, error) {
+	projectPath, err := d.getProjectPath(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	name := opts.Name
+	if name == "" {
+		project, err := d.portfolio.GetProject(d.ctx, &types.GetProjectOpts{
+			ID: opts.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		name = project.Project.Name + " Copy"
+	}
+
+	opid, err := d.operator.Create(d.ctx, func(ctx context.Context, opid uuid.UUID) (interface{}, error) {
+		result, err := d.portfolio.DuplicateProject(ctx, &types.DuplicateProjectOpts{
+			ID:           opts.ID,
+			Name:         name,
+			Path:         filepath.Join(projectPath, helpers.DisplayNameToFilename(name)),
+			ExcludeMedia: opts.ExcludeMedia,
+			Progress:     d.operationProgress(ctx, opid),
+		})
+		if err != nil {
+			d.logger.Error("failed to duplicate project", map[string]interface{}{
+				"error": err.Error(),
+				"id":    opts.ID,
+				"opid":  opid,
+			})
+			return nil, err
+		}
+
+		d.logger.Debug("project duplicated", map[string]interface{}{
+			"id":   result.ID,
+			"from": opts.ID,
+			"opid": opid,
+		})
+
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DuplicateProjectResult{
+		OperationID: opid,
+	}, nil
+}
+
+func (d *Driver) ExportProject(opts ExportProjectOpts) (*ExportProjectResult, error) {
+	opid, err := d.operator.Create(d.ctx, func(ctx context.Context, opid uuid.UUID) (interface{}, error) {
+		result, err := d.portfolio.ExportProject(ctx, &types.ExportProjectOpts{
+			ID:                opts.ID,
+			Path:              opts.Path,
+			Format:            opts.Format,
+			ExcludeMedia:      opts.ExcludeMedia,
+			ExcludeRenders:    opts.ExcludeRenders,
+			HonourIgnoreFiles: opts.HonourIgnoreFiles,
+			Progress:          d.operationProgress(ctx, opid),
+		})
+		if err != nil {
+			d.logger.Error("failed to export project", map[string]interface{}{
+				"error": err.Error(),
+				"id":    opts.ID,
+				"opid":  opid,
+			})
+			return nil, err
+		}
+
+		d.logger.Debug("project exported", map[string]interface{}{
+			"id":    opts.ID,
+			"path":  result.Path,
+			"files": len(result.Manifest.Files),
+			"opid":  opid,
+		})
+
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportProjectResult{
+		OperationID: opid,
+	}, nil
+}
+
+func (d *Driver) ImportProject(opts ImportProjectOpts) (*ImportProjectResult, error) {
+	projectPath, err := d.getProjectPath(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	opid, err := d.operator.Create(d.ctx, func(ctx context.Context, opid uuid.UUID) (interface{}, error) {
+		result, err := d.portfolio.ImportProject(ctx, &types.ImportProjectOpts{
+			ArchivePath: opts.ArchivePath,
+			Path:        projectPath,
+			Progress:    d.operationProgress(ctx, opid),
+		})
+		if err != nil {
+			d.logger.Error("failed to import project", map[string]interface{}{
+				"error":       err.Error(),
+				"archivePath": opts.ArchivePath,
+				"opid":        opid,
+			})
+			return nil, err
+		}
+
+		d.logger.Debug("project imported", map[string]interface{}{
+			"id":          result.ID,
+			"path":        result.Path,
+			"regenerated": result.Regenerated,
+			"opid":        opid,
+		})
+
+		imported := &ImportedProject{
+			ID:          result.ID,
+			Path:        result.Path,
+			Regenerated: result.Regenerated,
+		}
+
+		for _, dependency := range result.Manifest.Dependencies {
+			pack, err := d.findPackage(ctx, dependency.Reference)
+			if err != nil {
+				imported.UnknownReferences = append(imported.UnknownReferences, dependency.Reference)
+				continue
+			}
+
+			if pack.State != enums.PackageStateInstalled {
+				imported.MissingPackages = append(imported.MissingPackages, pack)
+			}
+		}
+
+		return imported, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportProjectResult{
+		OperationID: opid,
+	}, nil
+}
+
 func (d *Driver) AddProjectPackage(opts AddProjectPackageOpts) error {
 	if err := d.portfolio.AddProjectPackage(d.ctx, &types.AddProjectPackageOpts{
 		ID:        opts.ID,
@@ -191,8 +487,9 @@ func (d *Driver) RemoveProjectPackage(opts RemoveProjectPackageOpts) error {
 // UpdateProject updates a project
 func (d *Driver) UpdateProject(opts UpdateProjectOpts) error {
 	if err := d.portfolio.UpdateProject(d.ctx, &types.UpdateProjectOpts{
-		ID:   opts.ID,
-		Name: opts.Name,
+		ID:       opts.ID,
+		Name:     opts.Name,
+		MainFile: opts.MainFile,
 	}); err != nil {
 		d.logger.Error("failed to update project", map[string]interface{}{
 			"error": err.Error(),
@@ -218,11 +515,13 @@ func (d *Driver) RunProject(opts RunProjectOpts) error {
 	ctx := context.Background()
 
 	if err := d.portfolio.RunProject(ctx, &types.RunProjectOpts{
-		ID: opts.ID,
+		ID:       opts.ID,
+		FileName: opts.FileName,
 	}); err != nil {
 		d.logger.Error("failed to run project", map[string]interface{}{
-			"error": err.Error(),
-			"id":    opts.ID,
+			"error":    err.Error(),
+			"id":       opts.ID,
+			"fileName": opts.FileName,
 		})
 		return err
 	}
@@ -234,11 +533,88 @@ func (d *Driver) RunProject(opts RunProjectOpts) error {
 	return nil
 }
 
+func (d *Driver) PinProject(opts PinProjectOpts) error {
+	if err := d.portfolio.PinProject(d.ctx, &types.PinProjectOpts{
+		ID:     opts.ID,
+		Pinned: opts.Pinned,
+	}); err != nil {
+		d.logger.Error("failed to pin project", map[string]interface{}{
+			"error":  err.Error(),
+			"id":     opts.ID,
+			"pinned": opts.Pinned,
+		})
+		return err
+	}
+
+	d.logger.Debug("project pinned", map[string]interface{}{
+		"id":     opts.ID,
+		"pinned": opts.Pinned,
+	})
+
+	return nil
+}
+
+func (d *Driver) RegenerateProjectID(opts RegenerateProjectIDOpts) (*RegenerateProjectIDResult, error) {
+	result, err := d.portfolio.RegenerateProjectID(d.ctx, &types.RegenerateProjectIDOpts{
+		ID: opts.ID,
+	})
+	if err != nil {
+		d.logger.Error("failed to regenerate project id", map[string]interface{}{
+			"error": err.Error(),
+			"id":    opts.ID,
+		})
+		return nil, err
+	}
+
+	d.logger.Debug("project id regenerated", map[string]interface{}{
+		"id":    result.ID,
+		"oldID": opts.ID,
+	})
+
+	return &RegenerateProjectIDResult{
+		ID: result.ID,
+	}, nil
+}
+
+func (d *Driver) GetProjectReferences(opts GetProjectReferencesOpts) (*GetProjectReferencesResult, error) {
+	response, err := d.portfolio.GetProjectReferences(d.ctx, &types.GetProjectReferencesOpts{
+		ID: opts.ID,
+	})
+	if err != nil {
+		d.logger.Error("failed to get project references", map[string]interface{}{
+			"error": err.Error(),
+			"id":    opts.ID,
+		})
+		return nil, err
+	}
+
+	return &GetProjectReferencesResult{
+		References: response.References,
+	}, nil
+}
+
 func (d *Driver) RenderProject(opts RenderProjectOpts) error {
 	d.logger.Debug("project rendered", map[string]interface{}{"id": opts.ID})
 	return types.ErrNotImplement
 }
 
+// findPackage returns the package with the given reference from the catalog.
+func (d *Driver) findPackage(ctx context.Context, ref reference.Reference) (*types.Package, error) {
+	id, err := helpers.StringToUUID(ref.String())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.catalog.GetPackage(ctx, &types.GetPackageOpts{
+		ID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Package, nil
+}
+
 func (d *Driver) getDefaultBuild() (reference.Reference, error) {
 	config, err := d.rbConfigurator.Get()
 	if err != nil {
@@ -248,16 +624,25 @@ func (d *Driver) getDefaultBuild() (reference.Reference, error) {
 	return config.DefaultBuild, nil
 }
 
-func (d *Driver) getProjectPath() (string, error) {
+// getProjectPath returns the path of the given project root, or the first configured root if none is given.
+func (d *Driver) getProjectPath(root string) (string, error) {
 	config, err := d.configurator.Get()
 	if err != nil {
 		return "", err
 	}
 
-	if len(config.Project.Paths) == 0 {
+	if len(config.Project.Roots) == 0 {
 		return "", errors.New("no project path configured")
 	}
 
-	// TODO: support multiple project paths
-	return config.Project.Paths[0], nil
+	if root == "" {
+		return config.Project.Roots[0].Path, nil
+	}
+
+	index := findProjectRoot(config.Project.Roots, root)
+	if index == -1 {
+		return "", fmt.Errorf("project root %s is not configured", root)
+	}
+
+	return config.Project.Roots[index].Path, nil
 }
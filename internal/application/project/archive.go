@@ -0,0 +1,225 @@
+package project
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/enums"
+)
+
+type (
+	archiveWriter interface {
+		Create(name string, size int64, modTime time.Time) (io.Writer, error)
+		Close() error
+	}
+
+	zipArchiveWriter struct {
+		writer *zip.Writer
+	}
+
+	tarZstArchiveWriter struct {
+		encoder *zstd.Encoder
+		writer  *tar.Writer
+	}
+
+	// archiveWalkFunc is called for each file in an archive, in the order they were written.
+	archiveWalkFunc func(name string, reader io.Reader) error
+
+	archiveReader interface {
+		Walk(walkFn archiveWalkFunc) error
+		Close() error
+	}
+
+	zipArchiveReader struct {
+		reader *zip.ReadCloser
+	}
+
+	tarZstArchiveReader struct {
+		path string
+	}
+
+	// contextReader stops reading once its context is cancelled, so large files can be abandoned part way through.
+	contextReader struct {
+		ctx    context.Context
+		reader io.Reader
+	}
+)
+
+func newArchiveWriter(w io.Writer, format enums.ArchiveFormat) (archiveWriter, error) {
+	switch format {
+	case enums.ArchiveFormatZip:
+		return &zipArchiveWriter{
+			writer: zip.NewWriter(w),
+		}, nil
+	case enums.ArchiveFormatTarZst:
+		encoder, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, err
+		}
+
+		return &tarZstArchiveWriter{
+			encoder: encoder,
+			writer:  tar.NewWriter(encoder),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported archive format: %s", format)
+}
+
+func (a *zipArchiveWriter) Create(name string, size int64, modTime time.Time) (io.Writer, error) {
+	return a.writer.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modTime,
+	})
+}
+
+func (a *zipArchiveWriter) Close() error {
+	return a.writer.Close()
+}
+
+func (a *tarZstArchiveWriter) Create(name string, size int64, modTime time.Time) (io.Writer, error) {
+	if err := a.writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0644,
+		ModTime:  modTime,
+	}); err != nil {
+		return nil, err
+	}
+
+	return a.writer, nil
+}
+
+func (a *tarZstArchiveWriter) Close() error {
+	if err := a.writer.Close(); err != nil {
+		return err
+	}
+
+	return a.encoder.Close()
+}
+
+func openArchive(path string) (archiveReader, error) {
+	format, err := archiveFormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case enums.ArchiveFormatZip:
+		reader, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &zipArchiveReader{
+			reader: reader,
+		}, nil
+	case enums.ArchiveFormatTarZst:
+		if _, err := os.Stat(path); err != nil {
+			return nil, err
+		}
+
+		return &tarZstArchiveReader{
+			path: path,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported archive format: %s", format)
+}
+
+func archiveFormatFromPath(path string) (enums.ArchiveFormat, error) {
+	name := strings.ToLower(filepath.Base(path))
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return enums.ArchiveFormatZip, nil
+	case strings.HasSuffix(name, ".tar.zst"), strings.HasSuffix(name, ".tzst"):
+		return enums.ArchiveFormatTarZst, nil
+	}
+
+	return "", fmt.Errorf("unsupported archive: %s", filepath.Base(path))
+}
+
+func (a *zipArchiveReader) Walk(walkFn archiveWalkFunc) error {
+	for _, file := range a.reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		if err := walkZipFile(file, walkFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkZipFile(file *zip.File, walkFn archiveWalkFunc) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return walkFn(file.Name, reader)
+}
+
+func (a *zipArchiveReader) Close() error {
+	return a.reader.Close()
+}
+
+// Walk decompresses the archive from the start on each call, as tar archives can only be read in order.
+func (a *tarZstArchiveReader) Walk(walkFn archiveWalkFunc) error {
+	file, err := os.Open(a.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder, err := zstd.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+
+	reader := tar.NewReader(decoder)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := walkFn(header.Name, reader); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *tarZstArchiveReader) Close() error {
+	return nil
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.reader.Read(p)
+}
@@ -0,0 +1,219 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/enums"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/events"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/listoption"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	"github.com/rocketblend/rocketblend-desktop/internal/helpers"
+	rbhelpers "github.com/rocketblend/rocketblend/pkg/helpers"
+)
+
+func (r *Repository) RegenerateProjectID(ctx context.Context, opts *types.RegenerateProjectIDOpts) (*types.RegenerateProjectIDResult, error) {
+	project, err := r.get(ctx, opts.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	partners, err := r.conflictPartners(ctx, path.Clean(project.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	detail := project.Detail()
+	detail.ID = uuid.New()
+	if err := r.saveDetail(project.Path, detail, false, true); err != nil {
+		return nil, err
+	}
+
+	if err := r.index(ctx, project.Path); err != nil {
+		return nil, err
+	}
+
+	r.reindex(ctx, partners...)
+	r.emitEvent(ctx, detail.ID, events.ProjectUpdateChannel)
+
+	return &types.RegenerateProjectIDResult{
+		ID: detail.ID,
+	}, nil
+}
+
+// checkConflicts flags the project when its ID is already used by another project at a different location.
+// The project found second is given an ID derived from its path, so both remain in the store.
+func (r *Repository) checkConflicts(ctx context.Context, project *types.Project) error {
+	reference := path.Clean(project.Path)
+
+	existing, err := r.store.Get(ctx, project.ID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	if err == nil && existing.Reference != reference && r.claims(existing.Reference, project.ID) {
+		conflictID, err := helpers.StringToUUID(reference)
+		if err != nil {
+			return err
+		}
+
+		r.logger.Warn("duplicate project id", map[string]interface{}{
+			"id":        project.ID,
+			"reference": reference,
+			"existing":  existing.Reference,
+		})
+
+		project.ConflictID = project.ID
+		project.ID = conflictID
+		project.State = enums.ProjectStateConflict
+
+		if existing.State == string(enums.ProjectStateConflict) {
+			return nil
+		}
+
+		other, err := convertFromIndex(existing)
+		if err != nil {
+			return err
+		}
+
+		other.State = enums.ProjectStateConflict
+		index, err := convertToIndex(other)
+		if err != nil {
+			return err
+		}
+
+		return r.store.Insert(ctx, index)
+	}
+
+	copies, err := r.conflictingCopies(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, duplicate := range copies {
+		if path.Clean(duplicate.Path) != reference {
+			project.State = enums.ProjectStateConflict
+		}
+	}
+
+	return nil
+}
+
+// claims reports whether the project at the given location still uses the ID in its detail file.
+func (r *Repository) claims(reference string, id uuid.UUID) bool {
+	detail, err := rbhelpers.Load[types.Detail](r.validator, detailFilePath(reference))
+	if err != nil {
+		return false
+	}
+
+	return detail.ID == id
+}
+
+// conflictingCopies returns the projects indexed under a derived ID because they share the given ID.
+func (r *Repository) conflictingCopies(ctx context.Context, id uuid.UUID) ([]*types.Project, error) {
+	result, err := r.store.List(ctx,
+		listoption.WithType(indextype.Project),
+		listoption.WithState(string(enums.ProjectStateConflict)),
+		listoption.WithSize(10000),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var copies []*types.Project
+	for _, index := range result.Indexes {
+		project, err := convertFromIndex(index)
+		if err != nil {
+			return nil, err
+		}
+
+		if project.ConflictID == id {
+			copies = append(copies, project)
+		}
+	}
+
+	return copies, nil
+}
+
+// conflictPartners returns the paths of projects in conflict with any project indexed at the given references.
+func (r *Repository) conflictPartners(ctx context.Context, references ...string) ([]string, error) {
+	result, err := r.store.List(ctx,
+		listoption.WithType(indextype.Project),
+		listoption.WithReferences(references...),
+		listoption.WithState(string(enums.ProjectStateConflict)),
+		listoption.WithSize(10000),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var partners []string
+	for _, index := range result.Indexes {
+		project, err := convertFromIndex(index)
+		if err != nil {
+			return nil, err
+		}
+
+		if project.ConflictID != uuid.Nil {
+			original, err := r.store.Get(ctx, project.ConflictID)
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					continue
+				}
+
+				return nil, err
+			}
+
+			partners = append(partners, original.Reference)
+			continue
+		}
+
+		copies, err := r.conflictingCopies(ctx, project.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, duplicate := range copies {
+			partners = append(partners, duplicate.Path)
+		}
+	}
+
+	return partners, nil
+}
+
+// removeStale removes any other index left at the same location as the given index.
+func (r *Repository) removeStale(ctx context.Context, index *types.Index) error {
+	result, err := r.store.List(ctx,
+		listoption.WithType(indextype.Project),
+		listoption.WithReferences(index.Reference),
+		listoption.WithSize(10000),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range result.Indexes {
+		if existing.Reference == index.Reference && existing.ID != index.ID {
+			if err := r.store.Remove(ctx, existing.ID); err != nil && !errors.Is(err, store.ErrNotFound) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) reindex(ctx context.Context, paths ...string) {
+	for _, path := range paths {
+		if err := r.index(ctx, path); err != nil {
+			r.logger.Error("failed to reindex project", map[string]interface{}{
+				"error": err.Error(),
+				"path":  path,
+			})
+		}
+	}
+}
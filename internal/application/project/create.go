@@ -10,12 +10,23 @@ import (
 	"github.com/google/uuid"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/events"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	rbhelpers "github.com/rocketblend/rocketblend/pkg/helpers"
 	"github.com/rocketblend/rocketblend/pkg/reference"
 	rbtypes "github.com/rocketblend/rocketblend/pkg/types"
 )
 
 func (r *Repository) CreateProject(ctx context.Context, opts *types.CreateProjectOpts) (*types.CreateProjectResult, error) {
-	profile, err := r.newProfile(ctx, opts.Build)
+	var template *types.Template
+	if opts.TemplateID != uuid.Nil {
+		result, err := r.getTemplate(opts.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+
+		template = result
+	}
+
+	profile, err := r.newProjectProfile(ctx, opts.Build, template)
 	if err != nil {
 		return nil, err
 	}
@@ -32,15 +43,30 @@ func (r *Repository) CreateProject(ctx context.Context, opts *types.CreateProjec
 		}
 	}()
 
-	if err := r.createBlendFile(ctx, opts.DisplayName, filepath.Join(opts.Path, opts.BlendFileName), profile); err != nil {
-		return nil, err
+	var tags []string
+	if template != nil {
+		if err := rbhelpers.Save(r.validator, profileFilePath(opts.Path), profile, true, true); err != nil {
+			return nil, err
+		}
+
+		if err := r.applyTemplate(ctx, template, opts, profile); err != nil {
+			return nil, err
+		}
+
+		tags = template.Tags
+	} else {
+		if err := r.createBlendFile(ctx, opts.DisplayName, filepath.Join(opts.Path, opts.BlendFileName), profile); err != nil {
+			return nil, err
+		}
 	}
 
 	id := uuid.New()
 	if err := r.saveDetail(opts.Path, &types.Detail{
 		ID:        id,
 		Name:      opts.DisplayName,
+		Tags:      tags,
 		MediaPath: DefaultMediaPath,
+		MainFile:  opts.BlendFileName,
 	}, true, true); err != nil {
 		return nil, err
 	}
@@ -52,18 +78,52 @@ func (r *Repository) CreateProject(ctx context.Context, opts *types.CreateProjec
 	}, nil
 }
 
-func (r *Repository) newProfile(ctx context.Context, build reference.Reference) (*rbtypes.Profile, error) {
-	profiles := []*rbtypes.Profile{
+// newProjectProfile returns the template's profile when one is given, falling back to the build when the template has none.
+func (r *Repository) newProjectProfile(ctx context.Context, build reference.Reference, template *types.Template) (*rbtypes.Profile, error) {
+	if template == nil {
+		return r.newProfile(ctx, build)
+	}
+
+	profile := (&types.Project{Dependencies: template.Dependencies}).Profile()
+	hasBuild := false
+	for _, dependency := range profile.Dependencies {
+		if dependency.Type == rbtypes.PackageBuild {
+			hasBuild = true
+		}
+	}
+
+	if !hasBuild {
+		profile.Dependencies = append(profile.Dependencies, &rbtypes.Dependency{
+			Reference: build,
+			Type:      rbtypes.PackageBuild,
+		})
+	}
+
+	return r.tidyProfile(ctx, profile)
+}
+
+func (r *Repository) newProfile(ctx context.Context, build reference.Reference, addons ...reference.Reference) (*rbtypes.Profile, error) {
+	dependencies := []*rbtypes.Dependency{
 		{
-			Dependencies: []*rbtypes.Dependency{
-				{
-					Reference: build,
-					Type:      rbtypes.PackageBuild,
-				},
-			},
+			Reference: build,
+			Type:      rbtypes.PackageBuild,
 		},
 	}
 
+	for _, addon := range addons {
+		dependencies = append(dependencies, &rbtypes.Dependency{
+			Reference: addon,
+			Type:      rbtypes.PackageAddon,
+		})
+	}
+
+	return r.tidyProfile(ctx, &rbtypes.Profile{
+		Dependencies: dependencies,
+	})
+}
+
+func (r *Repository) tidyProfile(ctx context.Context, profile *rbtypes.Profile) (*rbtypes.Profile, error) {
+	profiles := []*rbtypes.Profile{profile}
 	if err := r.rbDriver.TidyProfiles(ctx, &rbtypes.TidyProfilesOpts{
 		Profiles: profiles,
 	}); err != nil {
@@ -0,0 +1,106 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/events"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	"github.com/rocketblend/rocketblend-desktop/internal/helpers"
+)
+
+func (r *Repository) DuplicateProject(ctx context.Context, opts *types.DuplicateProjectOpts) (result *types.DuplicateProjectResult, err error) {
+	project, err := r.get(ctx, opts.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	if _, err := os.Stat(opts.Path); err == nil {
+		return nil, fmt.Errorf("destination already exists: %s", opts.Path)
+	}
+
+	// The copy keeps its ignore file until it has its own identity, so it is never indexed under the original ID.
+	if err := createIgnoreFile(opts.Path); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			if rerr := os.RemoveAll(opts.Path); rerr != nil {
+				r.logger.Error("failed to remove incomplete project copy", map[string]interface{}{
+					"error": rerr.Error(),
+					"path":  opts.Path,
+				})
+			}
+
+			return
+		}
+
+		if rerr := removeIgnoreFile(opts.Path); rerr != nil {
+			r.logger.Error("failed to remove temporarily project ignore file", map[string]interface{}{"error": rerr})
+		}
+	}()
+
+	mediaPath := filepath.Join(project.Path, project.MediaPath)
+	ignoreFilePath := filepath.Join(project.Path, types.IgnoreFileName)
+	if err := copyTree(ctx, project.Path, opts.Path, func(path string, d fs.DirEntry) bool {
+		if opts.ExcludeMedia && path == mediaPath {
+			return true
+		}
+
+		return path == detailFilePath(project.Path) || path == ignoreFilePath
+	}, opts.Progress); err != nil {
+		return nil, fmt.Errorf("failed to copy project: %w", err)
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = project.Name + " Copy"
+	}
+
+	id := uuid.New()
+	if err := r.saveDetail(opts.Path, &types.Detail{
+		ID:        id,
+		Name:      name,
+		Tags:      project.Tags,
+		MediaPath: project.MediaPath,
+		MainFile:  project.FileName,
+	}, true, true); err != nil {
+		return nil, err
+	}
+
+	r.emitEvent(ctx, id, events.ProjectCreateChannel)
+
+	return &types.DuplicateProjectResult{
+		ID: id,
+	}, nil
+}
+
+// copyTree copies all files within src to dst, skipping any entries rejected by skip. Links are not followed.
+func copyTree(ctx context.Context, src string, dst string, skip skipFunc, progress types.ProgressFunc) error {
+	files, totalBytes, err := collectFiles(src, skip)
+	if err != nil {
+		return err
+	}
+
+	reporter := newProgressReporter(progress, totalBytes)
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := helpers.CopyFile(file.path, filepath.Join(dst, file.rel)); err != nil {
+			return err
+		}
+
+		reporter.add(file.size)
+	}
+
+	reporter.done()
+
+	return nil
+}
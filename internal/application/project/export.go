@@ -0,0 +1,185 @@
+package project
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+)
+
+func (r *Repository) ExportProject(ctx context.Context, opts *types.ExportProjectOpts) (result *types.ExportProjectResult, err error) {
+	project, err := r.get(ctx, opts.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	// Imports pick the format from the extension, so the archive must be written in the format its name says.
+	format, err := archiveFormatFromPath(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Format != "" && opts.Format != format {
+		return nil, fmt.Errorf("archive format %s does not match the extension of %s", opts.Format, filepath.Base(opts.Path))
+	}
+
+	skip, err := r.exportSkipFunc(project, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files, totalBytes, err := collectFiles(project.Path, skip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect project files: %w", err)
+	}
+
+	if _, err := os.Stat(opts.Path); err == nil {
+		return nil, fmt.Errorf("archive already exists: %s", opts.Path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(opts.Path), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Create(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		file.Close()
+		if err != nil {
+			if rerr := os.Remove(opts.Path); rerr != nil {
+				r.logger.Error("failed to remove incomplete archive", map[string]interface{}{
+					"error": rerr.Error(),
+					"path":  opts.Path,
+				})
+			}
+		}
+	}()
+
+	archive, err := newArchiveWriter(file, format)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &types.ArchiveManifest{
+		ID:           project.ID,
+		Name:         project.Name,
+		Folder:       filepath.Base(project.Path),
+		Dependencies: project.Dependencies,
+		CreatedAt:    time.Now(),
+	}
+
+	reporter := newProgressReporter(opts.Progress, totalBytes)
+	for _, projectFile := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		archiveFile, err := writeArchiveFile(ctx, archive, manifest.Folder, projectFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to archive %s: %w", projectFile.rel, err)
+		}
+
+		manifest.Files = append(manifest.Files, archiveFile)
+
+		reporter.add(projectFile.size)
+	}
+
+	reporter.done()
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	writer, err := archive.Create(types.ArchiveManifestFileName, int64(len(data)), manifest.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	return &types.ExportProjectResult{
+		Path:     opts.Path,
+		Manifest: manifest,
+	}, nil
+}
+
+func (r *Repository) exportSkipFunc(project *types.Project, opts *types.ExportProjectOpts) (skipFunc, error) {
+	mediaPath := filepath.Join(project.Path, project.MediaPath)
+	rendersPath := filepath.Join(project.Path, DefaultRendersPath)
+	ignoreFilePath := filepath.Join(project.Path, types.IgnoreFileName)
+
+	skips := []skipFunc{
+		func(path string, d fs.DirEntry) bool {
+			if opts.ExcludeMedia && path == mediaPath {
+				return true
+			}
+
+			if opts.ExcludeRenders && path == rendersPath {
+				return true
+			}
+
+			// The root ignore file hides the project from the library, so it is never exported.
+			return path == ignoreFilePath
+		},
+	}
+
+	if opts.HonourIgnoreFiles {
+		ignoreSkip, err := ignoreFileSkipFunc(project.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ignore files: %w", err)
+		}
+
+		skips = append(skips, ignoreSkip)
+	}
+
+	return skipAny(skips...), nil
+}
+
+// writeArchiveFile adds a project file to the archive under the given folder, returning its manifest entry.
+func writeArchiveFile(ctx context.Context, archive archiveWriter, folder string, projectFile *projectFile) (*types.ArchiveFile, error) {
+	source, err := os.Open(projectFile.path)
+	if err != nil {
+		return nil, err
+	}
+	defer source.Close()
+
+	name := path.Join(folder, filepath.ToSlash(projectFile.rel))
+	writer, err := archive.Create(name, projectFile.size, projectFile.modTime)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(writer, hash), &contextReader{ctx: ctx, reader: io.LimitReader(source, projectFile.size)}); err != nil {
+		return nil, err
+	}
+
+	return &types.ArchiveFile{
+		Path:     filepath.ToSlash(projectFile.rel),
+		Size:     projectFile.size,
+		Checksum: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
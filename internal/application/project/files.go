@@ -0,0 +1,224 @@
+package project
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+)
+
+// progressInterval is the minimum time between progress reports while copying project files.
+const progressInterval = 250 * time.Millisecond
+
+type (
+	projectFile struct {
+		path    string
+		rel     string
+		size    int64
+		modTime time.Time
+	}
+
+	skipFunc func(path string, d fs.DirEntry) bool
+
+	// progressReporter reports the bytes copied so far, at most once per progressInterval.
+	progressReporter struct {
+		progress     types.ProgressFunc
+		totalBytes   int64
+		currentBytes int64
+		start        time.Time
+		reportedAt   time.Time
+	}
+
+	// ignorePatterns holds the patterns from an ignore file, relative to the folder it was found in.
+	ignorePatterns struct {
+		dir      string
+		patterns []string
+	}
+)
+
+// collectFiles returns all regular files within root, skipping any entries rejected by skip. Links are not followed.
+func collectFiles(root string, skip skipFunc) ([]*projectFile, int64, error) {
+	var files []*projectFile
+	var totalBytes int64
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != root && skip != nil && skip(path, d) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, &projectFile{
+			path:    path,
+			rel:     rel,
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+		totalBytes += info.Size()
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return files, totalBytes, nil
+}
+
+// ignoreFileSkipFunc skips entries matched by ignore files within root. An empty ignore file skips the folder it is in,
+// otherwise each line is a pattern matched against the name and relative path of entries within that folder.
+func ignoreFileSkipFunc(root string) (skipFunc, error) {
+	var ignores []*ignorePatterns
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || d.Name() != types.IgnoreFileName {
+			return nil
+		}
+
+		patterns, err := readIgnoreFile(path)
+		if err != nil {
+			return err
+		}
+
+		ignores = append(ignores, &ignorePatterns{
+			dir:      filepath.Dir(path),
+			patterns: patterns,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func(path string, d fs.DirEntry) bool {
+		for _, ignore := range ignores {
+			if ignore.matches(root, path) {
+				return true
+			}
+		}
+
+		return false
+	}, nil
+}
+
+func (i *ignorePatterns) matches(root string, path string) bool {
+	rel, err := filepath.Rel(i.dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+
+	// An empty root ignore file hides the project from the library rather than skipping its contents.
+	if len(i.patterns) == 0 {
+		return i.dir != root
+	}
+
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range i.patterns {
+		if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
+			return true
+		}
+
+		if matched, _ := filepath.Match(strings.TrimPrefix(pattern, "/"), rel); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func readIgnoreFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		patterns = append(patterns, strings.TrimSuffix(line, "/"))
+	}
+
+	return patterns, scanner.Err()
+}
+
+// skipAny combines skip functions, skipping entries rejected by any of them.
+func skipAny(funcs ...skipFunc) skipFunc {
+	return func(path string, d fs.DirEntry) bool {
+		for _, f := range funcs {
+			if f != nil && f(path, d) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func newProgressReporter(progress types.ProgressFunc, totalBytes int64) *progressReporter {
+	return &progressReporter{
+		progress:   progress,
+		totalBytes: totalBytes,
+		start:      time.Now(),
+	}
+}
+
+// add records bytes as copied, reporting progress if the interval has passed. The final report is left to done.
+func (p *progressReporter) add(bytes int64) {
+	p.currentBytes += bytes
+	if p.currentBytes >= p.totalBytes || time.Since(p.reportedAt) < progressInterval {
+		return
+	}
+
+	p.report()
+}
+
+// done reports the final progress, regardless of when progress was last reported.
+func (p *progressReporter) done() {
+	p.report()
+}
+
+func (p *progressReporter) report() {
+	if p.progress == nil {
+		return
+	}
+
+	p.reportedAt = time.Now()
+	p.progress(&types.Progress{
+		CurrentBytes:   p.currentBytes,
+		TotalBytes:     p.totalBytes,
+		BytesPerSecond: float64(p.currentBytes) / p.reportedAt.Sub(p.start).Seconds(),
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/enums"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/fileserver"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
@@ -76,13 +77,39 @@ func convertToIndex(project *types.Project) (*types.Index, error) {
 		resources = append(resources, filepath.ToSlash(m.FilePath))
 	}
 
+	dependencies := make([]string, 0, len(project.Dependencies))
+	var builds, addons []string
+	for _, d := range project.Dependencies {
+		dependencies = append(dependencies, d.Reference.String())
+		switch d.Type {
+		case enums.PackageTypeBuild:
+			builds = append(builds, d.Reference.String())
+		case enums.PackageTypeAddon:
+			addons = append(addons, d.Reference.String())
+		}
+	}
+
+	files := make([]string, 0, len(project.BlendFiles))
+	for _, f := range project.BlendFiles {
+		files = append(files, f.FileName)
+	}
+
 	return &types.Index{
-		ID:        project.ID,
-		Name:      project.Name,
-		Type:      indextype.Project,
-		Reference: path.Clean(project.Path),
-		Resources: resources,
-		Data:      string(data),
+		ID:           project.ID,
+		Name:         project.Name,
+		Type:         indextype.Project,
+		Reference:    path.Clean(project.Path),
+		State:        string(project.State),
+		Resources:    resources,
+		Tags:         project.Tags,
+		Dependencies: dependencies,
+		Builds:       builds,
+		Addons:       addons,
+		Files:        files,
+		Pinned:       project.Pinned,
+		LastOpened:   project.LastOpenedAt,
+		Date:         project.UpdatedAt,
+		Data:         string(data),
 	}, nil
 }
 
@@ -0,0 +1,210 @@
+package project
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/events"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	rbhelpers "github.com/rocketblend/rocketblend/pkg/helpers"
+)
+
+func (r *Repository) ImportProject(ctx context.Context, opts *types.ImportProjectOpts) (result *types.ImportProjectResult, err error) {
+	archive, err := openArchive(opts.ArchivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer archive.Close()
+
+	manifest, err := readManifest(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	projectPath := filepath.Join(opts.Path, manifest.Folder)
+	if _, err := os.Stat(projectPath); err == nil {
+		return nil, fmt.Errorf("destination already exists: %s", projectPath)
+	}
+
+	// We create a temporary ignore file to avoid adding the project to the index before it is fully extracted.
+	if err := createIgnoreFile(projectPath); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			if rerr := os.RemoveAll(projectPath); rerr != nil {
+				r.logger.Error("failed to remove incomplete project import", map[string]interface{}{
+					"error": rerr.Error(),
+					"path":  projectPath,
+				})
+			}
+
+			return
+		}
+
+		if rerr := removeIgnoreFile(projectPath); rerr != nil {
+			r.logger.Error("failed to remove temporarily project ignore file", map[string]interface{}{"error": rerr})
+		}
+	}()
+
+	if err := extractArchive(ctx, archive, manifest, projectPath, opts.Progress); err != nil {
+		return nil, fmt.Errorf("failed to extract archive: %w", err)
+	}
+
+	detail, err := rbhelpers.Load[types.Detail](r.validator, detailFilePath(projectPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project detail: %w", err)
+	}
+
+	regenerated := false
+	if _, err := r.store.Get(ctx, detail.ID); err == nil {
+		detail.ID = uuid.New()
+		if err := r.saveDetail(projectPath, detail, false, true); err != nil {
+			return nil, err
+		}
+
+		regenerated = true
+	} else if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	r.emitEvent(ctx, detail.ID, events.ProjectCreateChannel)
+
+	return &types.ImportProjectResult{
+		ID:          detail.ID,
+		Path:        projectPath,
+		Manifest:    manifest,
+		Regenerated: regenerated,
+	}, nil
+}
+
+var errManifestFound = errors.New("manifest found")
+
+func readManifest(archive archiveReader) (*types.ArchiveManifest, error) {
+	var manifest *types.ArchiveManifest
+	if err := archive.Walk(func(name string, reader io.Reader) error {
+		if name != types.ArchiveManifestFileName {
+			return nil
+		}
+
+		manifest = &types.ArchiveManifest{}
+		if err := json.NewDecoder(reader).Decode(manifest); err != nil {
+			return fmt.Errorf("failed to read manifest: %w", err)
+		}
+
+		return errManifestFound
+	}); err != nil && !errors.Is(err, errManifestFound) {
+		return nil, err
+	}
+
+	if manifest == nil {
+		return nil, errors.New("archive has no manifest")
+	}
+
+	if manifest.Folder == "" || manifest.Folder != filepath.Base(manifest.Folder) || !filepath.IsLocal(manifest.Folder) {
+		return nil, fmt.Errorf("invalid project folder in manifest: %q", manifest.Folder)
+	}
+
+	for _, file := range manifest.Files {
+		if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+			return nil, fmt.Errorf("invalid file path in manifest: %q", file.Path)
+		}
+	}
+
+	return manifest, nil
+}
+
+// extractArchive writes the files listed in the manifest into the project path, verifying the checksum of each.
+func extractArchive(ctx context.Context, archive archiveReader, manifest *types.ArchiveManifest, projectPath string, progress types.ProgressFunc) error {
+	files := make(map[string]*types.ArchiveFile, len(manifest.Files))
+	var totalBytes int64
+	for _, file := range manifest.Files {
+		files[file.Path] = file
+		totalBytes += file.Size
+	}
+
+	reporter := newProgressReporter(progress, totalBytes)
+	prefix := manifest.Folder + "/"
+	if err := archive.Walk(func(name string, reader io.Reader) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if name == types.ArchiveManifestFileName {
+			return nil
+		}
+
+		file, ok := files[strings.TrimPrefix(name, prefix)]
+		if !strings.HasPrefix(name, prefix) || !ok {
+			return fmt.Errorf("file not listed in manifest: %s", name)
+		}
+
+		delete(files, file.Path)
+
+		if err := extractFile(ctx, reader, file, filepath.Join(projectPath, filepath.FromSlash(path.Clean(file.Path)))); err != nil {
+			return err
+		}
+
+		reporter.add(file.Size)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(files) > 0 {
+		missing := make([]string, 0, len(files))
+		for name := range files {
+			missing = append(missing, name)
+		}
+
+		sort.Strings(missing)
+		return fmt.Errorf("files missing from archive: %s", strings.Join(missing, ", "))
+	}
+
+	reporter.done()
+
+	return nil
+}
+
+func extractFile(ctx context.Context, reader io.Reader, file *types.ArchiveFile, destination string) error {
+	if err := os.MkdirAll(filepath.Dir(destination), os.ModePerm); err != nil {
+		return err
+	}
+
+	output, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	// Read at most one byte past the manifest size, so an oversized entry fails without being written in full.
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(output, hash), io.LimitReader(&contextReader{ctx: ctx, reader: reader}, file.Size+1))
+	if err != nil {
+		return err
+	}
+
+	if size > file.Size {
+		return fmt.Errorf("file larger than expected: %s", file.Path)
+	}
+
+	if size != file.Size || hex.EncodeToString(hash.Sum(nil)) != file.Checksum {
+		return fmt.Errorf("checksum mismatch: %s", file.Path)
+	}
+
+	return output.Close()
+}
@@ -10,13 +10,13 @@ import (
 
 func (r *Repository) ListProjects(ctx context.Context, opts ...listoption.ListOption) (*types.ListProjectsResponse, error) {
 	opts = append(opts, listoption.WithType(indextype.Project))
-	indexes, err := r.store.List(ctx, opts...)
+	result, err := r.store.List(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	projects := make([]*types.Project, 0, len(indexes))
-	for _, index := range indexes {
+	projects := make([]*types.Project, 0, len(result.Indexes))
+	for _, index := range result.Indexes {
 		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
@@ -31,10 +31,25 @@ func (r *Repository) ListProjects(ctx context.Context, opts ...listoption.ListOp
 
 	r.logger.Debug("found projects", map[string]interface{}{
 		"projects": len(projects),
-		"indexes":  len(indexes),
+		"indexes":  len(result.Indexes),
 	})
 
 	return &types.ListProjectsResponse{
-		Projects: projects,
+		Projects:  projects,
+		Total:     result.Total,
+		Took:      result.Took,
+		Facets:    result.Facets,
+		Fragments: result.Fragments,
+	}, nil
+}
+
+func (r *Repository) SuggestProjects(ctx context.Context, prefix string) (*types.SuggestResponse, error) {
+	suggestions, err := r.store.Suggest(ctx, prefix, indextype.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SuggestResponse{
+		Suggestions: suggestions,
 	}, nil
 }
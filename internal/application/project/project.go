@@ -8,6 +8,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/flowshot-io/x/pkg/logger"
@@ -17,13 +18,17 @@ import (
 	"github.com/rocketblend/rocketblend-desktop/internal/application/store"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/watcher"
+	"github.com/rocketblend/rocketblend-desktop/internal/blendfile"
 	"github.com/rocketblend/rocketblend-desktop/internal/helpers"
 	rbhelpers "github.com/rocketblend/rocketblend/pkg/helpers"
 	"github.com/rocketblend/rocketblend/pkg/reference"
 	rbtypes "github.com/rocketblend/rocketblend/pkg/types"
 )
 
-const DefaultMediaPath = "/media"
+const (
+	DefaultMediaPath   = "/media"
+	DefaultRendersPath = "/renders"
+)
 
 type (
 	Repository struct {
@@ -40,6 +45,16 @@ type (
 		store      types.Store
 		watcher    types.Watcher
 		dispatcher types.Dispatcher
+
+		templatePath       string
+		thumbnailCachePath string
+
+		referenceCache map[string]*referenceCacheEntry
+		referenceMu    sync.Mutex
+
+		userStatePath string
+		userState     map[uuid.UUID]*types.ProjectUserState
+		userStateMu   sync.Mutex
 	}
 
 	Options struct {
@@ -56,6 +71,10 @@ type (
 		Store      types.Store
 		Dispatcher types.Dispatcher
 
+		TemplatePath       string
+		ThumbnailCachePath string
+		UserStatePath      string
+
 		WatcherDebounceDuration time.Duration
 	}
 
@@ -116,6 +135,24 @@ func WithBlender(blender types.Blender) Option {
 	}
 }
 
+func WithTemplatePath(path string) Option {
+	return func(o *Options) {
+		o.TemplatePath = path
+	}
+}
+
+func WithThumbnailCachePath(path string) Option {
+	return func(o *Options) {
+		o.ThumbnailCachePath = path
+	}
+}
+
+func WithUserStatePath(path string) Option {
+	return func(o *Options) {
+		o.UserStatePath = path
+	}
+}
+
 func WithWatcherDebounceDuration(duration time.Duration) Option {
 	return func(o *Options) {
 		o.WatcherDebounceDuration = duration
@@ -169,11 +206,33 @@ func New(opts ...Option) (*Repository, error) {
 		return nil, err
 	}
 
+	userState, err := loadUserState(options.UserStatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user project state: %w", err)
+	}
+
+	r := &Repository{
+		logger:             options.Logger,
+		configurator:       options.Configurator,
+		validator:          options.Validator,
+		rbConfigurator:     options.RBConfigurator,
+		rbRepository:       options.RBRepository,
+		rbDriver:           options.RBDriver,
+		blender:            options.Blender,
+		store:              options.Store,
+		dispatcher:         options.Dispatcher,
+		templatePath:       options.TemplatePath,
+		thumbnailCachePath: options.ThumbnailCachePath,
+		referenceCache:     make(map[string]*referenceCacheEntry),
+		userStatePath:      options.UserStatePath,
+		userState:          userState,
+	}
+
 	// TODO: This whole watcher thing is a bit of a mess.
 	watcher, err := watcher.New(
 		watcher.WithLogger(options.Logger),
 		watcher.WithEventDebounceDuration(options.WatcherDebounceDuration),
-		watcher.WithPaths(config.Project.Paths...),
+		watcher.WithPaths(config.Project.Paths()...),
 		watcher.WithIsWatchableFileFunc(func(path string) bool {
 			for _, ext := range ValidExtensions() {
 				if filepath.Ext(path) == ext {
@@ -184,56 +243,47 @@ func New(opts ...Option) (*Repository, error) {
 			return false
 		}),
 		watcher.WithResolveObjectPathFunc(func(filePath string) string {
-			// TODO: This is a bit of a mess. We should probably just use the store to resolve the project path.
-			rootPath := resolveRootPath(filePath, config.Project.Paths)
-			if rootPath == "" {
+			config, err := options.Configurator.Get()
+			if err != nil {
+				options.Logger.Error("failed to get config", map[string]interface{}{"error": err})
 				return ""
 			}
 
-			return findProjectRoot(filePath, rootPath)
-		}),
-		watcher.WithUpdateObjectFunc(func(path string) error {
-			project, err := load(options.Validator, options.RBConfigurator, path)
-			if err != nil {
-				return err
+			// TODO: This is a bit of a mess. We should probably just use the store to resolve the project path.
+			root := config.Project.Root(filePath)
+			if root == nil {
+				return ""
 			}
 
-			index, err := convertToIndex(project)
+			return findProjectRoot(filePath, root.Path)
+		}),
+		watcher.WithFollowSymlinksFunc(func(rootPath string) bool {
+			config, err := options.Configurator.Get()
 			if err != nil {
-				return err
+				options.Logger.Error("failed to get config", map[string]interface{}{"error": err})
+				return false
 			}
 
-			options.Logger.Debug("updating project index", map[string]interface{}{
-				"id":        index.ID,
-				"reference": index.Reference,
-			})
-
-			return options.Store.Insert(context.Background(), index)
+			root := config.Project.Root(rootPath)
+			return root != nil && root.FollowSymlinks
+		}),
+		watcher.WithUpdateObjectFunc(func(path string) error {
+			return r.index(context.Background(), path)
+		}),
+		watcher.WithUpdateObjectsFunc(func(paths []string) error {
+			return r.indexBatch(context.Background(), paths)
 		}),
 		watcher.WithRemoveObjectFunc(func(removePath string) error {
-			if err := options.Store.RemoveByReference(context.Background(), path.Clean(removePath)); err != nil && !errors.Is(err, store.ErrNotFound) {
-				return err
-			}
-
-			return nil
+			return r.remove(context.Background(), removePath)
 		}),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Repository{
-		logger:         options.Logger,
-		configurator:   options.Configurator,
-		validator:      options.Validator,
-		rbConfigurator: options.RBConfigurator,
-		rbRepository:   options.RBRepository,
-		rbDriver:       options.RBDriver,
-		blender:        options.Blender,
-		store:          options.Store,
-		dispatcher:     options.Dispatcher,
-		watcher:        watcher,
-	}, nil
+	r.watcher = watcher
+
+	return r, nil
 }
 
 func (r *Repository) Close() error {
@@ -250,6 +300,166 @@ func ValidExtensions() []string {
 	return extensions
 }
 
+// index loads the project at the given path and adds it to the store, flagging any ID conflicts.
+func (r *Repository) index(ctx context.Context, projectPath string) error {
+	project, err := r.loadProject(ctx, projectPath)
+	if err != nil {
+		return err
+	}
+
+	index, err := r.prepareIndex(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	return r.store.Insert(ctx, index)
+}
+
+// indexBatch indexes the projects at the given paths, writing them to the store in as few batches as possible.
+// Projects that fail to load are removed from the store, matching the behaviour of a single update.
+func (r *Repository) indexBatch(ctx context.Context, projectPaths []string) error {
+	// Conflict detection reads from the store, so any pending project sharing an ID must be written first.
+	pending := make(map[uuid.UUID]struct{})
+	indexes := make([]*types.Index, 0, len(projectPaths))
+	flush := func() error {
+		if err := r.store.InsertBatch(ctx, indexes); err != nil {
+			return err
+		}
+
+		pending = make(map[uuid.UUID]struct{})
+		indexes = indexes[:0]
+		return nil
+	}
+
+	for _, projectPath := range projectPaths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		project, err := r.loadProject(ctx, projectPath)
+		if err == nil {
+			if _, ok := pending[project.ID]; ok {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+
+			id := project.ID
+			var index *types.Index
+			if index, err = r.prepareIndex(ctx, project); err == nil {
+				pending[id] = struct{}{}
+				pending[index.ID] = struct{}{}
+				indexes = append(indexes, index)
+				continue
+			}
+		}
+
+		r.logger.Error("failed to index project", map[string]interface{}{
+			"error": err.Error(),
+			"path":  projectPath,
+		})
+
+		if err := r.remove(ctx, projectPath); err != nil {
+			r.logger.Error("failed to remove project", map[string]interface{}{
+				"error": err.Error(),
+				"path":  projectPath,
+			})
+		}
+	}
+
+	return flush()
+}
+
+// loadProject loads the project at the given path, along with the details gathered from its blend files.
+func (r *Repository) loadProject(ctx context.Context, projectPath string) (*types.Project, error) {
+	config, err := r.configurator.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := load(r.validator, r.rbConfigurator, config.Project.Root(projectPath), projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.attachBlendThumbnail(project); err != nil {
+		r.logger.Warn("failed to read blend file thumbnail", map[string]interface{}{
+			"error": err.Error(),
+			"path":  projectPath,
+		})
+	}
+
+	if err := r.checkBuildVersion(ctx, project); err != nil {
+		r.logger.Warn("failed to check project build version", map[string]interface{}{
+			"error": err.Error(),
+			"path":  projectPath,
+		})
+	}
+
+	if err := r.checkReferences(project); err != nil {
+		r.logger.Warn("failed to check project references", map[string]interface{}{
+			"error": err.Error(),
+			"path":  projectPath,
+		})
+	}
+
+	return project, nil
+}
+
+// prepareIndex resolves conflicts with already indexed projects and converts the project into an index ready to be stored.
+func (r *Repository) prepareIndex(ctx context.Context, project *types.Project) (*types.Index, error) {
+	if err := r.checkConflicts(ctx, project); err != nil {
+		return nil, err
+	}
+
+	r.applyUserState(project)
+
+	index, err := convertToIndex(project)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop any index left at the same location under a previous ID.
+	if err := r.removeStale(ctx, index); err != nil {
+		return nil, err
+	}
+
+	r.logger.Debug("updating project index", map[string]interface{}{
+		"id":        index.ID,
+		"reference": index.Reference,
+		"state":     index.State,
+	})
+
+	return index, nil
+}
+
+// remove removes all projects indexed within the given path.
+func (r *Repository) remove(ctx context.Context, removePath string) error {
+	references := []string{path.Clean(removePath)}
+
+	// Linked projects are indexed under their canonical path. Deleted linked projects can't be resolved here, so the
+	// watcher passes their canonical path instead.
+	if canonicalPath, err := filepath.EvalSymlinks(removePath); err == nil && canonicalPath != removePath {
+		references = append(references, path.Clean(canonicalPath))
+	}
+
+	partners, err := r.conflictPartners(ctx, references...)
+	if err != nil {
+		return err
+	}
+
+	for _, reference := range references {
+		if err := r.store.RemoveByReference(ctx, reference); err != nil && !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
+	}
+
+	// Projects that conflicted with a removed project may no longer be in conflict.
+	r.reindex(ctx, partners...)
+
+	return nil
+}
+
 func (r *Repository) saveDetail(path string, detail *types.Detail, ensurePath bool, override bool) error {
 	if err := rbhelpers.Save(r.validator, detailFilePath(path), detail, ensurePath, override); err != nil {
 		return err
@@ -258,11 +468,30 @@ func (r *Repository) saveDetail(path string, detail *types.Detail, ensurePath bo
 	return nil
 }
 
-func load(validator rbtypes.Validator, configurator rbtypes.Configurator, path string) (*types.Project, error) {
+func load(validator rbtypes.Validator, configurator rbtypes.Configurator, root *types.ProjectRootConfig, path string) (*types.Project, error) {
 	if ignoreProject(path) {
 		return nil, errors.New("project is ignored")
 	}
 
+	rootPath := ""
+	linkPath := ""
+	if root != nil {
+		rootPath = root.Path
+
+		// Projects found through a link are indexed under their canonical path, so they are never indexed twice.
+		if root.FollowSymlinks {
+			canonicalPath, err := filepath.EvalSymlinks(path)
+			if err != nil {
+				return nil, err
+			}
+
+			if canonicalPath != filepath.Clean(path) {
+				linkPath = path
+				path = canonicalPath
+			}
+		}
+	}
+
 	blendFilePaths, err := findFilePathForExtension(path, rbtypes.BlendFileExtension)
 	if err != nil {
 		return nil, err
@@ -278,8 +507,7 @@ func load(validator rbtypes.Validator, configurator rbtypes.Configurator, path s
 		return nil, err
 	}
 
-	blendFilePath := blendFilePaths[0]
-	detail, err := loadOrCreateDetail(validator, path, blendFilePath)
+	detail, err := loadOrCreateDetail(validator, path, blendFilePaths[0])
 	if err != nil {
 		return nil, err
 	}
@@ -288,6 +516,20 @@ func load(validator rbtypes.Validator, configurator rbtypes.Configurator, path s
 		return nil, errors.New("media path must be relative")
 	}
 
+	blendFiles, err := loadBlendFiles(blendFilePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fall back to the first file if the main file has been removed or renamed.
+	mainFile := blendFiles[0]
+	for _, f := range blendFiles {
+		if f.FileName == detail.MainFile {
+			mainFile = f
+			break
+		}
+	}
+
 	modTime, err := helpers.GetModTime(path)
 	if err != nil {
 		return nil, err
@@ -299,27 +541,47 @@ func load(validator rbtypes.Validator, configurator rbtypes.Configurator, path s
 	}
 
 	return &types.Project{
-		ID:           detail.ID,
-		Name:         detail.Name,
-		Tags:         detail.Tags,
-		Path:         path,
-		MediaPath:    detail.MediaPath,
-		FileName:     filepath.Base(blendFilePath),
-		Dependencies: convertDependencies(profile.Dependencies),
-		Strict:       profile.Strict,
-		Media:        media,
-		UpdatedAt:    modTime,
+		ID:               detail.ID,
+		Name:             detail.Name,
+		Tags:             detail.Tags,
+		Path:             path,
+		Root:             rootPath,
+		LinkPath:         linkPath,
+		MediaPath:        detail.MediaPath,
+		FileName:         mainFile.FileName,
+		BlendFiles:       blendFiles,
+		SavedWithVersion: mainFile.SavedWithVersion,
+		Dependencies:     convertDependencies(profile.Dependencies),
+		Strict:           profile.Strict,
+		Media:            media,
+		State:            enums.ProjectStateReady,
+		UpdatedAt:        modTime,
 	}, nil
 }
 
-func resolveRootPath(filePath string, rootPaths []string) string {
-	for _, rootPath := range rootPaths {
-		if strings.HasPrefix(filePath, rootPath) {
-			return rootPath
+func loadBlendFiles(blendFilePaths []string) ([]*types.BlendFile, error) {
+	blendFiles := make([]*types.BlendFile, 0, len(blendFilePaths))
+	for _, blendFilePath := range blendFilePaths {
+		info, err := os.Stat(blendFilePath)
+		if err != nil {
+			return nil, err
+		}
+
+		// The saved version is informational, so files with an unreadable header are still loaded.
+		savedWithVersion := ""
+		if header, err := blendfile.ReadHeader(blendFilePath); err == nil {
+			savedWithVersion = header.String()
 		}
+
+		blendFiles = append(blendFiles, &types.BlendFile{
+			FileName:         filepath.Base(blendFilePath),
+			Size:             info.Size(),
+			ModTime:          info.ModTime(),
+			SavedWithVersion: savedWithVersion,
+		})
 	}
 
-	return ""
+	return blendFiles, nil
 }
 
 func findProjectRoot(filePath, rootPath string) string {
@@ -408,6 +670,7 @@ func loadOrCreateDetail(validator rbtypes.Validator, path string, blendFilePath
 				ID:        uuid.New(),
 				Name:      helpers.FilenameToDisplayName(blendFilePath),
 				MediaPath: DefaultMediaPath,
+				MainFile:  filepath.Base(blendFilePath),
 			}
 
 			if err := rbhelpers.Save(validator, detailFilePath, detail, true, false); err != nil {
@@ -0,0 +1,138 @@
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rocketblend/rocketblend-desktop/internal/application/enums"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	"github.com/rocketblend/rocketblend-desktop/internal/blendfile"
+)
+
+// tileTokens are replaced by Blender with a tile number, so paths containing them refer to several files.
+var tileTokens = []string{"<UDIM>", "<UVTILE>"}
+
+type referenceCacheEntry struct {
+	modTime    time.Time
+	references []*blendfile.Reference
+}
+
+func (r *Repository) GetProjectReferences(ctx context.Context, opts *types.GetProjectReferencesOpts) (*types.GetProjectReferencesResponse, error) {
+	project, err := r.get(ctx, opts.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	references, err := r.projectReferences(project)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetProjectReferencesResponse{
+		References: references,
+	}, nil
+}
+
+// checkReferences counts the linked libraries and images that can't be found, flagging the project if there are any.
+func (r *Repository) checkReferences(project *types.Project) error {
+	references, err := r.projectReferences(project)
+	if err != nil {
+		return err
+	}
+
+	project.BrokenLinks = 0
+	for _, reference := range references {
+		if reference.Missing {
+			project.BrokenLinks++
+		}
+	}
+
+	if project.BrokenLinks > 0 && project.State == enums.ProjectStateReady {
+		project.State = enums.ProjectStateBrokenLinks
+	}
+
+	return nil
+}
+
+func (r *Repository) projectReferences(project *types.Project) ([]*types.ProjectReference, error) {
+	blendFilePath := filepath.Join(project.Path, project.FileName)
+	references, err := r.readReferences(blendFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.ProjectReference, 0, len(references))
+	for _, reference := range references {
+		resolvedPath := resolveReferencePath(filepath.Dir(blendFilePath), reference.Path)
+		result = append(result, &types.ProjectReference{
+			Type:         reference.Type,
+			Name:         reference.Name,
+			Path:         reference.Path,
+			ResolvedPath: resolvedPath,
+			Packed:       reference.Packed,
+			Missing:      !reference.Packed && !referenceExists(resolvedPath),
+		})
+	}
+
+	return result, nil
+}
+
+// readReferences reads the references from a blend file, reusing the previous result while the file is unchanged.
+func (r *Repository) readReferences(blendFilePath string) ([]*blendfile.Reference, error) {
+	info, err := os.Stat(blendFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	r.referenceMu.Lock()
+	entry, ok := r.referenceCache[blendFilePath]
+	r.referenceMu.Unlock()
+
+	if ok && entry.modTime.Equal(info.ModTime()) {
+		return entry.references, nil
+	}
+
+	references, err := blendfile.ReadReferences(blendFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	r.referenceMu.Lock()
+	r.referenceCache[blendFilePath] = &referenceCacheEntry{
+		modTime:    info.ModTime(),
+		references: references,
+	}
+	r.referenceMu.Unlock()
+
+	return references, nil
+}
+
+// resolveReferencePath resolves a path stored in a blend file. Paths starting with "//" are relative to the blend file.
+func resolveReferencePath(blendDir string, path string) string {
+	path = strings.ReplaceAll(path, "\\", "/")
+	if strings.HasPrefix(path, "//") {
+		return filepath.Join(blendDir, filepath.FromSlash(path[2:]))
+	}
+
+	// Keep drive letter paths as they are, even on platforms without them, so they show up as missing.
+	if filepath.IsAbs(filepath.FromSlash(path)) || (len(path) > 1 && path[1] == ':') {
+		return filepath.FromSlash(path)
+	}
+
+	return filepath.Join(blendDir, filepath.FromSlash(path))
+}
+
+func referenceExists(path string) bool {
+	for _, token := range tileTokens {
+		if strings.Contains(path, token) {
+			matches, err := filepath.Glob(strings.ReplaceAll(path, token, "*"))
+			return err == nil && len(matches) > 0
+		}
+	}
+
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -20,7 +20,7 @@ func (r *Repository) refresh(ctx context.Context) error {
 		return err
 	}
 
-	if err := r.watcher.SetPaths(config.Project.Paths...); err != nil {
+	if err := r.watcher.SetPaths(config.Project.Paths()...); err != nil {
 		return err
 	}
 
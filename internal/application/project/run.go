@@ -2,6 +2,7 @@ package project
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 
 	"github.com/google/uuid"
@@ -11,19 +12,27 @@ import (
 )
 
 func (r *Repository) RunProject(ctx context.Context, opts *types.RunProjectOpts) error {
-	if err := r.run(ctx, opts.ID); err != nil {
+	if err := r.run(ctx, opts.ID, opts.FileName); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *Repository) run(ctx context.Context, id uuid.UUID) error {
+func (r *Repository) run(ctx context.Context, id uuid.UUID, fileName string) error {
 	project, err := r.get(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	if fileName == "" {
+		fileName = project.FileName
+	}
+
+	if project.BlendFile(fileName) == nil {
+		return fmt.Errorf("blend file not found in project: %s", fileName)
+	}
+
 	result, err := r.rbDriver.ResolveProfiles(ctx, &rbtypes.ResolveProfilesOpts{
 		Profiles: []*rbtypes.Profile{
 			project.Profile(),
@@ -33,11 +42,18 @@ func (r *Repository) run(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
+	if err := r.markOpened(ctx, project); err != nil {
+		r.logger.Warn("failed to record project as opened", map[string]interface{}{
+			"error": err.Error(),
+			"id":    project.ID,
+		})
+	}
+
 	go func() {
 		if err := r.blender.Run(ctx, &rbtypes.RunOpts{
 			BlenderOpts: rbtypes.BlenderOpts{
 				BlendFile: &rbtypes.BlendFile{
-					Path:         filepath.Join(project.Path, project.FileName),
+					Path:         filepath.Join(project.Path, fileName),
 					Dependencies: result.Installations[0],
 					Strict:       project.Strict,
 				},
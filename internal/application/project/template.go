@@ -0,0 +1,336 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	"github.com/rocketblend/rocketblend-desktop/internal/helpers"
+	rbhelpers "github.com/rocketblend/rocketblend/pkg/helpers"
+	rbtypes "github.com/rocketblend/rocketblend/pkg/types"
+)
+
+const (
+	TemplateDirName = "templates"
+
+	templateBlendFileName   = "template.blend"
+	templatePlaceholderName = "placeholder"
+)
+
+var defaultTemplateFolders = []string{"media", "renders", "textures"}
+
+func (r *Repository) ListTemplates(ctx context.Context) (*types.ListTemplatesResponse, error) {
+	entries, err := os.ReadDir(r.templatePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &types.ListTemplatesResponse{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	templates := make([]*types.Template, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		template, err := r.loadTemplate(filepath.Join(r.templatePath, entry.Name()))
+		if err != nil {
+			r.logger.Warn("skipping invalid template", map[string]interface{}{
+				"error": err.Error(),
+				"path":  entry.Name(),
+			})
+			continue
+		}
+
+		templates = append(templates, template)
+	}
+
+	return &types.ListTemplatesResponse{
+		Templates: templates,
+	}, nil
+}
+
+func (r *Repository) CreateTemplate(ctx context.Context, opts *types.CreateTemplateOpts) (*types.CreateTemplateResult, error) {
+	if err := validateTemplateFolders(opts.Folders); err != nil {
+		return nil, err
+	}
+
+	profile, err := r.newProfile(ctx, opts.Build, opts.Addons...)
+	if err != nil {
+		return nil, err
+	}
+
+	folders := opts.Folders
+	if len(folders) == 0 {
+		folders = defaultTemplateFolders
+	}
+
+	id := uuid.New()
+	templatePath := r.templateDir(id)
+	if err := r.writeTemplate(templatePath, &types.TemplateDetail{
+		ID:          id,
+		Name:        opts.Name,
+		Description: opts.Description,
+		Tags:        opts.Tags,
+		Folders:     folders,
+	}, profile, func() error {
+		if err := r.createBlendFile(ctx, opts.Name, filepath.Join(templatePath, templateBlendFileName), profile); err != nil {
+			return err
+		}
+
+		return writePlaceholder(filepath.Join(templatePath, DefaultMediaPath, templatePlaceholderName+".png"))
+	}); err != nil {
+		return nil, err
+	}
+
+	return &types.CreateTemplateResult{
+		ID: id,
+	}, nil
+}
+
+func (r *Repository) SaveProjectAsTemplate(ctx context.Context, opts *types.SaveProjectAsTemplateOpts) (*types.CreateTemplateResult, error) {
+	project, err := r.get(ctx, opts.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	folders, err := findFolders(project.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = project.Name
+	}
+
+	id := uuid.New()
+	templatePath := r.templateDir(id)
+	if err := r.writeTemplate(templatePath, &types.TemplateDetail{
+		ID:          id,
+		Name:        name,
+		Description: opts.Description,
+		Tags:        project.Tags,
+		Folders:     folders,
+	}, project.Profile(), func() error {
+		if err := helpers.CopyFile(filepath.Join(project.Path, project.FileName), filepath.Join(templatePath, project.FileName)); err != nil {
+			return err
+		}
+
+		// The project's thumbnail becomes the template's placeholder, when it has one.
+		for _, media := range project.Media {
+			if media.Thumbnail {
+				return helpers.CopyFile(media.FilePath, filepath.Join(templatePath, DefaultMediaPath, templatePlaceholderName+filepath.Ext(media.FilePath)))
+			}
+		}
+
+		return writePlaceholder(filepath.Join(templatePath, DefaultMediaPath, templatePlaceholderName+".png"))
+	}); err != nil {
+		return nil, err
+	}
+
+	return &types.CreateTemplateResult{
+		ID: id,
+	}, nil
+}
+
+// applyTemplate copies the template's folder skeleton, media and starter blend file into the project path.
+func (r *Repository) applyTemplate(ctx context.Context, template *types.Template, opts *types.CreateProjectOpts, profile *rbtypes.Profile) error {
+	for _, folder := range template.Folders {
+		if err := os.MkdirAll(filepath.Join(opts.Path, filepath.FromSlash(folder)), os.ModePerm); err != nil {
+			return err
+		}
+	}
+
+	mediaPath := filepath.Join(template.Path, DefaultMediaPath)
+	if err := filepath.WalkDir(mediaPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(template.Path, path)
+		if err != nil {
+			return err
+		}
+
+		return helpers.CopyFile(path, filepath.Join(opts.Path, rel))
+	}); err != nil {
+		return fmt.Errorf("failed to copy template media: %w", err)
+	}
+
+	blendFilePath := filepath.Join(opts.Path, opts.BlendFileName)
+	if template.FileName == "" {
+		return r.createBlendFile(ctx, opts.DisplayName, blendFilePath, profile)
+	}
+
+	return helpers.CopyFile(filepath.Join(template.Path, template.FileName), blendFilePath)
+}
+
+func (r *Repository) getTemplate(id uuid.UUID) (*types.Template, error) {
+	template, err := r.loadTemplate(r.templateDir(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (r *Repository) loadTemplate(path string) (*types.Template, error) {
+	detail, err := rbhelpers.Load[types.TemplateDetail](r.validator, filepath.Join(path, types.TemplateFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTemplateFolders(detail.Folders); err != nil {
+		return nil, err
+	}
+
+	var dependencies []*types.Dependency
+	if _, err := os.Stat(profileFilePath(path)); err == nil {
+		profile, err := rbhelpers.Load[rbtypes.Profile](r.validator, profileFilePath(path))
+		if err != nil {
+			return nil, err
+		}
+
+		dependencies = convertDependencies(profile.Dependencies)
+	}
+
+	// Templates without a starter blend file have one created with the project.
+	fileName := ""
+	if blendFilePaths, err := findFilePathForExtension(path, rbtypes.BlendFileExtension); err == nil {
+		fileName = filepath.Base(blendFilePaths[0])
+	}
+
+	return &types.Template{
+		ID:           detail.ID,
+		Name:         detail.Name,
+		Description:  detail.Description,
+		Tags:         detail.Tags,
+		Folders:      detail.Folders,
+		Path:         path,
+		FileName:     fileName,
+		Dependencies: dependencies,
+	}, nil
+}
+
+// validateTemplateFolders checks that every folder stays within the project or template it is created in.
+func validateTemplateFolders(folders []string) error {
+	for _, folder := range folders {
+		if !filepath.IsLocal(filepath.FromSlash(folder)) {
+			return fmt.Errorf("invalid template folder %q: must be a relative path within the project", folder)
+		}
+	}
+
+	return nil
+}
+
+// writeTemplate saves a new template, removing it again if any step fails.
+func (r *Repository) writeTemplate(path string, detail *types.TemplateDetail, profile *rbtypes.Profile, populate func() error) (err error) {
+	if r.templatePath == "" {
+		return errors.New("template path is not set")
+	}
+
+	defer func() {
+		if err != nil {
+			if rerr := os.RemoveAll(path); rerr != nil {
+				r.logger.Error("failed to remove incomplete template", map[string]interface{}{
+					"error": rerr.Error(),
+					"path":  path,
+				})
+			}
+		}
+	}()
+
+	for _, folder := range detail.Folders {
+		if err := os.MkdirAll(filepath.Join(path, filepath.FromSlash(folder)), os.ModePerm); err != nil {
+			return err
+		}
+	}
+
+	if err := rbhelpers.Save(r.validator, profileFilePath(path), profile, true, false); err != nil {
+		return err
+	}
+
+	if err := populate(); err != nil {
+		return err
+	}
+
+	return rbhelpers.Save(r.validator, filepath.Join(path, types.TemplateFileName), detail, true, false)
+}
+
+func (r *Repository) templateDir(id uuid.UUID) string {
+	return filepath.Join(r.templatePath, id.String())
+}
+
+// findFolders returns the relative paths of all folders within a project, excluding hidden folders.
+func findFolders(path string) ([]string, error) {
+	var folders []string
+	err := filepath.WalkDir(path, func(folderPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() || folderPath == path {
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(path, folderPath)
+		if err != nil {
+			return err
+		}
+
+		folders = append(folders, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find project folders: %w", err)
+	}
+
+	return folders, nil
+}
+
+// writePlaceholder writes a plain image to stand in for a thumbnail until the project has its own.
+func writePlaceholder(path string) error {
+	img := image.NewRGBA(image.Rect(0, 0, 640, 360))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.RGBA{R: 38, G: 38, B: 38, A: 255}}, image.Point{}, draw.Src)
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := png.Encode(file, img); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,86 @@
+package project
+
+import (
+	"errors"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	"github.com/rocketblend/rocketblend-desktop/internal/blendfile"
+	"github.com/rocketblend/rocketblend-desktop/internal/helpers"
+)
+
+const ThumbnailCacheDirName = "thumbnails"
+
+// attachBlendThumbnail adds the thumbnail embedded in the project's blend file when the project has no thumbnail of its own.
+func (r *Repository) attachBlendThumbnail(project *types.Project) error {
+	if r.thumbnailCachePath == "" {
+		return nil
+	}
+
+	for _, media := range project.Media {
+		if media.Thumbnail {
+			return nil
+		}
+	}
+
+	thumbnailPath, err := r.cacheBlendThumbnail(filepath.Join(project.Path, project.FileName))
+	if err != nil {
+		if errors.Is(err, blendfile.ErrNoThumbnail) {
+			return nil
+		}
+
+		return err
+	}
+
+	media, err := loadMedia(thumbnailPath)
+	if err != nil {
+		return err
+	}
+
+	media.Thumbnail = true
+	project.Media = append(project.Media, media)
+
+	return nil
+}
+
+// cacheBlendThumbnail writes the blend file's thumbnail to the cache as a PNG, unless the cached copy is up to date.
+func (r *Repository) cacheBlendThumbnail(blendFilePath string) (string, error) {
+	id, err := helpers.StringToUUID(blendFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	thumbnailPath := filepath.Join(r.thumbnailCachePath, id.String()+".png")
+
+	blendInfo, err := os.Stat(blendFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	if cacheInfo, err := os.Stat(thumbnailPath); err == nil && !cacheInfo.ModTime().Before(blendInfo.ModTime()) {
+		return thumbnailPath, nil
+	}
+
+	img, err := blendfile.ReadThumbnail(blendFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(r.thumbnailCachePath, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	file, err := os.Create(thumbnailPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if err := png.Encode(file, img); err != nil {
+		return "", err
+	}
+
+	return thumbnailPath, file.Close()
+}
@@ -2,6 +2,7 @@ package project
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/rocketblend/rocketblend-desktop/internal/application/events"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
@@ -22,6 +23,14 @@ func (r *Repository) UpdateProject(ctx context.Context, opts *types.UpdateProjec
 		detail.Tags = *opts.Tags
 	}
 
+	if opts.MainFile != nil {
+		if project.BlendFile(*opts.MainFile) == nil {
+			return fmt.Errorf("blend file not found in project: %s", *opts.MainFile)
+		}
+
+		detail.MainFile = *opts.MainFile
+	}
+
 	if err := r.saveDetail(project.Path, detail, false, true); err != nil {
 		return err
 	}
@@ -0,0 +1,134 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/events"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+)
+
+// UserStateFileName is the file holding per-user project state, such as pinned projects. It lives in the application
+// directory rather than the project folder, so users sharing a project on a network drive don't overwrite each other.
+const UserStateFileName = "projects.json"
+
+func (r *Repository) PinProject(ctx context.Context, opts *types.PinProjectOpts) error {
+	project, err := r.get(ctx, opts.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.updateUserState(project.ID, func(state *types.ProjectUserState) {
+		state.Pinned = opts.Pinned
+	}); err != nil {
+		return err
+	}
+
+	if err := r.refreshUserState(ctx, project); err != nil {
+		return err
+	}
+
+	r.emitEvent(ctx, project.ID, events.ProjectUpdateChannel)
+
+	return nil
+}
+
+// markOpened records the time the project was last opened by the user.
+func (r *Repository) markOpened(ctx context.Context, project *types.Project) error {
+	if err := r.updateUserState(project.ID, func(state *types.ProjectUserState) {
+		now := time.Now()
+		state.LastOpenedAt = &now
+	}); err != nil {
+		return err
+	}
+
+	return r.refreshUserState(ctx, project)
+}
+
+// refreshUserState updates the indexed project with the user's state, without reloading it from disk.
+func (r *Repository) refreshUserState(ctx context.Context, project *types.Project) error {
+	r.applyUserState(project)
+
+	index, err := convertToIndex(project)
+	if err != nil {
+		return err
+	}
+
+	return r.store.Insert(ctx, index)
+}
+
+func (r *Repository) applyUserState(project *types.Project) {
+	r.userStateMu.Lock()
+	defer r.userStateMu.Unlock()
+
+	project.Pinned = false
+	project.LastOpenedAt = nil
+	if state, ok := r.userState[project.ID]; ok {
+		project.Pinned = state.Pinned
+		project.LastOpenedAt = state.LastOpenedAt
+	}
+}
+
+func (r *Repository) updateUserState(id uuid.UUID, update func(state *types.ProjectUserState)) error {
+	r.userStateMu.Lock()
+	defer r.userStateMu.Unlock()
+
+	state, ok := r.userState[id]
+	if !ok {
+		state = &types.ProjectUserState{}
+		r.userState[id] = state
+	}
+
+	update(state)
+
+	return saveUserState(r.userStatePath, r.userState)
+}
+
+func loadUserState(path string) (map[uuid.UUID]*types.ProjectUserState, error) {
+	state := make(map[uuid.UUID]*types.ProjectUserState)
+	if path == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// saveUserState writes to a temporary file first, so the existing state survives a failed write.
+func saveUserState(path string, state map[uuid.UUID]*types.ProjectUserState) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, path)
+}
@@ -0,0 +1,100 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rocketblend/rocketblend-desktop/internal/application/enums"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	"github.com/rocketblend/rocketblend/pkg/reference"
+	rbtypes "github.com/rocketblend/rocketblend/pkg/types"
+)
+
+// checkBuildVersion flags projects whose blend file was saved with a newer version of Blender than the
+// build in their profile. Opening such a file with the older build can lose data.
+func (r *Repository) checkBuildVersion(ctx context.Context, project *types.Project) error {
+	project.SavedWithNewerBuild = false
+	if project.SavedWithVersion == "" {
+		return nil
+	}
+
+	var build reference.Reference
+	for _, dependency := range project.Dependencies {
+		if dependency.Type == enums.PackageTypeBuild {
+			build = dependency.Reference
+			break
+		}
+	}
+
+	if build == "" {
+		return nil
+	}
+
+	result, err := r.rbRepository.GetPackages(ctx, &rbtypes.GetPackagesOpts{
+		References: []reference.Reference{build},
+	})
+	if err != nil {
+		return err
+	}
+
+	pack, ok := result.Packs[build]
+	if !ok || pack.Version == nil {
+		return nil
+	}
+
+	newer, err := isNewerVersion(project.SavedWithVersion, pack.Version.String())
+	if err != nil {
+		return err
+	}
+
+	project.SavedWithNewerBuild = newer
+
+	return nil
+}
+
+// isNewerVersion reports whether the saved version is newer than the build version. Blend files only
+// record the major and minor version, so patch versions are ignored.
+func isNewerVersion(saved string, build string) (bool, error) {
+	savedMajor, savedMinor, err := parseMajorMinor(saved)
+	if err != nil {
+		return false, err
+	}
+
+	buildMajor, buildMinor, err := parseMajorMinor(build)
+	if err != nil {
+		return false, err
+	}
+
+	if savedMajor != buildMajor {
+		return savedMajor > buildMajor, nil
+	}
+
+	return savedMinor > buildMinor, nil
+}
+
+func parseMajorMinor(version string) (int, int, error) {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid version: %s", version)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version: %s", version)
+	}
+
+	// Ignore any suffix on the minor version, such as "2-alpha".
+	digits := strings.IndexFunc(parts[1], func(r rune) bool { return r < '0' || r > '9' })
+	if digits == -1 {
+		digits = len(parts[1])
+	}
+
+	minor, err := strconv.Atoi(parts[1][:digits])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version: %s", version)
+	}
+
+	return major, minor, nil
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"errors"
+	"os"
+)
+
+type (
+	ExportSnapshotOpts struct {
+		Path string `json:"path"`
+	}
+
+	ExportSnapshotResult struct {
+		Path string `json:"path"`
+	}
+
+	ImportSnapshotOpts struct {
+		Path string `json:"path"`
+	}
+)
+
+// ExportSnapshot writes everything held in the store to a snapshot file, for diagnosing a user's library.
+func (d *Driver) ExportSnapshot(opts ExportSnapshotOpts) (*ExportSnapshotResult, error) {
+	if opts.Path == "" {
+		return nil, errors.New("snapshot path is required")
+	}
+
+	file, err := os.Create(opts.Path)
+	if err != nil {
+		d.logger.Error("failed to create snapshot file", map[string]interface{}{
+			"error": err.Error(),
+			"path":  opts.Path,
+		})
+		return nil, err
+	}
+
+	if err := d.store.Export(d.ctx, file); err != nil {
+		file.Close()
+		d.logger.Error("failed to export snapshot", map[string]interface{}{
+			"error": err.Error(),
+			"path":  opts.Path,
+		})
+		return nil, err
+	}
+
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	d.logger.Info("snapshot exported", map[string]interface{}{
+		"path": opts.Path,
+	})
+
+	return &ExportSnapshotResult{
+		Path: opts.Path,
+	}, nil
+}
+
+// ImportSnapshot loads a snapshot file into the store, reproducing the library state it was taken from.
+func (d *Driver) ImportSnapshot(opts ImportSnapshotOpts) error {
+	file, err := os.Open(opts.Path)
+	if err != nil {
+		d.logger.Error("failed to open snapshot file", map[string]interface{}{
+			"error": err.Error(),
+			"path":  opts.Path,
+		})
+		return err
+	}
+	defer file.Close()
+
+	if err := d.store.Import(d.ctx, file); err != nil {
+		d.logger.Error("failed to import snapshot", map[string]interface{}{
+			"error": err.Error(),
+			"path":  opts.Path,
+		})
+		return err
+	}
+
+	d.logger.Info("snapshot imported", map[string]interface{}{
+		"path": opts.Path,
+	})
+
+	return nil
+}
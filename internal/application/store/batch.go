@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/events"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+)
+
+// InsertBatch inserts the indexes in a single write, emitting one batch event rather than an event per index.
+func (s *Store) InsertBatch(ctx context.Context, indexes []*types.Index) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	event, err := s.insertBatch(indexes)
+	if err != nil {
+		return err
+	}
+
+	s.emitBatchEvent(ctx, event)
+
+	s.logger.Debug("batch indexed successful", map[string]interface{}{
+		"count": len(indexes),
+	})
+
+	for _, indexType := range event.IndexTypes {
+		s.pruneExpired(ctx, indexType)
+	}
+
+	return nil
+}
+
+func (s *Store) insertBatch(indexes []*types.Index) (*events.StoreBatchEvent, error) {
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+
+	batches := make(map[indextype.IndexType]*bleve.Batch)
+	ids := make([]uuid.UUID, 0, len(indexes))
+	ops := make([]string, 0, len(indexes))
+	indexTypes := make([]indextype.IndexType, 0, len(indexes))
+	written := make(map[uuid.UUID]struct{}, len(indexes))
+	for _, index := range indexes {
+		batch, err := s.batchFor(batches, index.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		op, err := s.insertOp(index)
+		if err != nil {
+			return nil, err
+		}
+
+		// An index written earlier in the same batch is updated by the later write.
+		if _, ok := written[index.ID]; ok {
+			op = types.StoreOpUpdate
+		}
+
+		if err := batch.Index(index.ID.String(), index); err != nil {
+			return nil, err
+		}
+
+		written[index.ID] = struct{}{}
+		ids = append(ids, index.ID)
+		ops = append(ops, op)
+		indexTypes = append(indexTypes, index.Type)
+	}
+
+	if err := s.runBatches(batches); err != nil {
+		return nil, err
+	}
+
+	for i, index := range indexes {
+		s.record(ops[i], index.ID, index.Type, index)
+	}
+
+	return &events.StoreBatchEvent{
+		Revision:   s.revision,
+		Inserted:   ids,
+		IndexTypes: uniqueIndexTypes(indexTypes),
+	}, nil
+}
+
+// RemoveBatch removes the indexes in a single write, emitting one batch event. IDs that aren't indexed are ignored.
+func (s *Store) RemoveBatch(ctx context.Context, ids []uuid.UUID) error {
+	return s.removeBatch(ctx, ids)
+}
+
+func (s *Store) removeBatch(ctx context.Context, ids []uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	batches := make(map[indextype.IndexType]*bleve.Batch)
+	removed := make([]uuid.UUID, 0, len(ids))
+	indexTypes := make([]indextype.IndexType, 0, len(ids))
+	for _, id := range ids {
+		index, err := s.get(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+
+			return err
+		}
+
+		batch, err := s.batchFor(batches, index.Type)
+		if err != nil {
+			return err
+		}
+
+		batch.Delete(id.String())
+		removed = append(removed, id)
+		indexTypes = append(indexTypes, index.Type)
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	s.changeMu.Lock()
+	if err := s.runBatches(batches); err != nil {
+		s.changeMu.Unlock()
+		return err
+	}
+
+	for i, id := range removed {
+		s.record(types.StoreOpRemove, id, indexTypes[i], nil)
+	}
+
+	revision := s.revision
+	s.changeMu.Unlock()
+
+	s.emitBatchEvent(ctx, &events.StoreBatchEvent{
+		Revision:   revision,
+		Removed:    removed,
+		IndexTypes: uniqueIndexTypes(indexTypes),
+	})
+
+	s.logger.Debug("batch removed successful", map[string]interface{}{
+		"count": len(removed),
+	})
+
+	return nil
+}
+
+// batchFor returns the batch for the index holding the given type, creating it if needed.
+func (s *Store) batchFor(batches map[indextype.IndexType]*bleve.Batch, indexType indextype.IndexType) (*bleve.Batch, error) {
+	if batch, ok := batches[indexType]; ok {
+		return batch, nil
+	}
+
+	index, err := s.indexFor(indexType)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := index.NewBatch()
+	batches[indexType] = batch
+
+	return batch, nil
+}
+
+func (s *Store) runBatches(batches map[indextype.IndexType]*bleve.Batch) error {
+	for indexType, batch := range batches {
+		if err := s.indexes[indexType].Batch(batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) emitBatchEvent(ctx context.Context, event *events.StoreBatchEvent) {
+	if err := s.dispatcher.EmitEvent(ctx, events.StoreBatchChannel, event); err != nil {
+		s.logger.Error("error emitting event", map[string]interface{}{
+			"err": err,
+		})
+	}
+}
+
+func uniqueIndexTypes(indexTypes []indextype.IndexType) []indextype.IndexType {
+	seen := make(map[indextype.IndexType]struct{}, len(indexTypes))
+	unique := make([]indextype.IndexType, 0, len(indexTypes))
+	for _, indexType := range indexTypes {
+		if _, ok := seen[indexType]; ok {
+			continue
+		}
+
+		seen[indexType] = struct{}{}
+		unique = append(unique, indexType)
+	}
+
+	return unique
+}
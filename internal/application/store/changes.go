@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+)
+
+// ChangeLogSize is the number of most recent changes kept for clients catching up with Changes.
+const ChangeLogSize = 10000
+
+// Changes returns the changes made after the given revision. If some of them are no longer kept, no changes are
+// returned and Reset is set instead.
+func (s *Store) Changes(ctx context.Context, sinceRevision uint64) (*types.StoreChanges, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+
+	result := &types.StoreChanges{
+		Revision: s.revision,
+		Changes:  []*types.StoreChange{},
+	}
+
+	if sinceRevision >= s.revision {
+		return result, nil
+	}
+
+	if len(s.changes) == 0 || s.changes[0].Revision > sinceRevision+1 {
+		result.Reset = true
+		return result, nil
+	}
+
+	start := sort.Search(len(s.changes), func(i int) bool {
+		return s.changes[i].Revision > sinceRevision
+	})
+
+	result.Changes = append(result.Changes, s.changes[start:]...)
+	return result, nil
+}
+
+// record gives a write the next revision and adds it to the change log. Callers must hold changeMu, so
+// revisions follow the order writes are made in. The returned change holds the written index, for its event.
+func (s *Store) record(op string, id uuid.UUID, indexType indextype.IndexType, index *types.Index) *types.StoreChange {
+	s.revision++
+	change := &types.StoreChange{
+		Revision:  s.revision,
+		Op:        op,
+		ID:        id,
+		IndexType: indexType,
+		Index:     index,
+	}
+
+	logged := *change
+	logged.Index = changeLogIndex(index)
+
+	// The log is trimmed once it's twice its size, rather than on every write.
+	s.changes = append(s.changes, &logged)
+	if len(s.changes) >= 2*ChangeLogSize {
+		s.changes = append([]*types.StoreChange(nil), s.changes[len(s.changes)-ChangeLogSize:]...)
+	}
+
+	return change
+}
+
+// changeLogIndex copies an index for the change log, so later changes by the caller don't rewrite history. Data is
+// left out, as it can be large and is read with Get.
+func changeLogIndex(index *types.Index) *types.Index {
+	if index == nil {
+		return nil
+	}
+
+	logged := *index
+	logged.Data = ""
+	logged.Resources = slices.Clone(index.Resources)
+	logged.Tags = slices.Clone(index.Tags)
+	logged.Dependencies = slices.Clone(index.Dependencies)
+	logged.Builds = slices.Clone(index.Builds)
+	logged.Addons = slices.Clone(index.Addons)
+	logged.Files = slices.Clone(index.Files)
+	logged.Operations = slices.Clone(index.Operations)
+
+	if index.LastOpened != nil {
+		lastOpened := *index.LastOpened
+		logged.LastOpened = &lastOpened
+	}
+
+	return &logged
+}
+
+// insertOp returns whether writing the index inserts a new index or updates an existing one.
+func (s *Store) insertOp(index *types.Index) (string, error) {
+	typeIndex, err := s.indexFor(index.Type)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := typeIndex.Document(index.ID.String())
+	if err != nil {
+		return "", err
+	}
+
+	if existing != nil {
+		return types.StoreOpUpdate, nil
+	}
+
+	return types.StoreOpInsert, nil
+}
@@ -1,21 +1,78 @@
 package store
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
 	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
 )
 
-func newIndexMapping() mapping.IndexMapping {
+const sortAnalyzer = "sort"
+
+// ErrUnknownIndexType is returned when writing an index without a known type.
+var ErrUnknownIndexType = errors.New("unknown index type")
+
+// indexTypes are the types of index kept by the store, each in an index of its own.
+var indexTypes = []indextype.IndexType{
+	indextype.Project,
+	indextype.Package,
+	indextype.Operation,
+	indextype.Metric,
+}
+
+// indexFor returns the index holding indexes of the given type.
+func (s *Store) indexFor(indexType indextype.IndexType) (bleve.Index, error) {
+	index, ok := s.indexes[indexType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownIndexType, indexType)
+	}
+
+	return index, nil
+}
+
+// searchIndex returns the index to search for the given type. Unknown types search across every index.
+func (s *Store) searchIndex(indexType indextype.IndexType) bleve.Index {
+	if index, ok := s.indexes[indexType]; ok {
+		return index
+	}
+
+	return s.alias
+}
+
+// newIndexMappingFor returns the mapping for the given type of index. Projects and packages are searched by users,
+// so get the full library mapping, while operations and metrics are only looked up by reference, name, state and date.
+func newIndexMappingFor(indexType indextype.IndexType) (mapping.IndexMapping, error) {
+	switch indexType {
+	case indextype.Project, indextype.Package:
+		return newIndexMapping()
+	default:
+		return newRecordMapping(), nil
+	}
+}
+
+func newIndexMapping() (mapping.IndexMapping, error) {
 	mapping := bleve.NewDocumentMapping()
 	// mapping.Dynamic = false
 
 	// source data store - this is where original doc will be stored
-	dataTextFieldMapping := bleve.NewTextFieldMapping()
-	dataTextFieldMapping.Store = true
-	dataTextFieldMapping.Index = false
-	dataTextFieldMapping.IncludeInAll = false
-	dataTextFieldMapping.IncludeTermVectors = false
-	mapping.AddFieldMappingsAt("data", dataTextFieldMapping)
+	mapping.AddFieldMappingsAt("data", newDataFieldMapping())
+
+	// text fields that can be sorted on are also kept whole (lowercased) under a separate name for sorting and exact matches.
+	mapping.AddFieldMappingsAt("name", bleve.NewTextFieldMapping(), newSortFieldMapping("sortName"))
+	mapping.AddFieldMappingsAt("state", bleve.NewTextFieldMapping(), newSortFieldMapping("sortState"))
+	mapping.AddFieldMappingsAt("category", bleve.NewTextFieldMapping(), newSortFieldMapping("sortCategory"))
+
+	// tags, dependency references and file names are matched and counted as whole values.
+	mapping.AddFieldMappingsAt("tags", newKeywordFieldMapping(true))
+	mapping.AddFieldMappingsAt("dependencies", newKeywordFieldMapping(false))
+	mapping.AddFieldMappingsAt("builds", newKeywordFieldMapping(false))
+	mapping.AddFieldMappingsAt("addons", newKeywordFieldMapping(false))
+	mapping.AddFieldMappingsAt("files", newKeywordFieldMapping(true))
 
 	// create
 	indexMapping := bleve.NewIndexMapping()
@@ -23,5 +80,54 @@ func newIndexMapping() mapping.IndexMapping {
 	indexMapping.TypeField = "type"
 	indexMapping.DefaultAnalyzer = "en"
 
+	if err := indexMapping.AddCustomAnalyzer(sortAnalyzer, map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     single.Name,
+		"token_filters": []string{lowercase.Name},
+	}); err != nil {
+		return nil, err
+	}
+
+	return indexMapping, nil
+}
+
+func newRecordMapping() mapping.IndexMapping {
+	mapping := bleve.NewDocumentMapping()
+	mapping.AddFieldMappingsAt("data", newDataFieldMapping())
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.AddDocumentMapping("index", mapping)
+	indexMapping.TypeField = "type"
+	indexMapping.DefaultAnalyzer = "en"
+
 	return indexMapping
 }
+
+// newDataFieldMapping stores the original document without indexing it.
+func newDataFieldMapping() *mapping.FieldMapping {
+	fieldMapping := bleve.NewTextFieldMapping()
+	fieldMapping.Store = true
+	fieldMapping.Index = false
+	fieldMapping.IncludeInAll = false
+	fieldMapping.IncludeTermVectors = false
+
+	return fieldMapping
+}
+
+func newKeywordFieldMapping(includeInAll bool) *mapping.FieldMapping {
+	fieldMapping := bleve.NewKeywordFieldMapping()
+	fieldMapping.IncludeInAll = includeInAll
+
+	return fieldMapping
+}
+
+func newSortFieldMapping(name string) *mapping.FieldMapping {
+	fieldMapping := bleve.NewTextFieldMapping()
+	fieldMapping.Name = name
+	fieldMapping.Analyzer = sortAnalyzer
+	fieldMapping.Store = false
+	fieldMapping.IncludeInAll = false
+	fieldMapping.IncludeTermVectors = false
+
+	return fieldMapping
+}
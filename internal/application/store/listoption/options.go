@@ -1,15 +1,50 @@
 package listoption
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/highlight/highlighter/html"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
 )
 
+// DefaultSize is the number of results returned when no size is given.
+const DefaultSize = 50
+
+// sortFields maps the fields results can be sorted by to the indexed field used for sorting. Text fields are
+// sorted by an untokenized copy, so multi-word values sort as a whole.
+var sortFields = map[string]string{
+	"name":       "sortName",
+	"state":      "sortState",
+	"category":   "sortCategory",
+	"date":       "date",
+	"lastOpened": "lastOpened",
+}
+
+// FacetSize is the maximum number of terms returned for each facet.
+const FacetSize = 50
+
+// facetFields maps the fields that can be faceted to the indexed field holding their whole values.
+var facetFields = map[string]string{
+	"tags":         "tags",
+	"dependencies": "dependencies",
+	"category":     "sortCategory",
+	"state":        "sortState",
+}
+
+// highlightFields are the fields fragments are returned for when highlighting.
+var highlightFields = []string{"name", "tags", "files"}
+
 // TODO: This package should be moved/changed.
 type (
+	SortField struct {
+		Field string
+		Desc  bool
+	}
+
 	ListOptions struct {
 		Query      string
 		Type       indextype.IndexType
@@ -19,6 +54,12 @@ type (
 		Resource   string
 		Operation  string
 		State      string
+		Tags       []string
+		Dependency string
+		Pinned     bool
+		Sort       []SortField
+		Facets     []string
+		Highlight  bool
 		Size       int
 		From       int
 		StartTime  time.Time
@@ -76,6 +117,53 @@ func WithState(state string) ListOption {
 	}
 }
 
+// WithTags only matches indexes carrying all of the given tags.
+func WithTags(tags ...string) ListOption {
+	return func(o *ListOptions) {
+		o.Tags = append(o.Tags, tags...)
+	}
+}
+
+// WithDependency only matches indexes that depend on the given package reference.
+func WithDependency(reference string) ListOption {
+	return func(o *ListOptions) {
+		o.Dependency = reference
+	}
+}
+
+// WithPinned only matches indexes that have been pinned.
+func WithPinned() ListOption {
+	return func(o *ListOptions) {
+		o.Pinned = true
+	}
+}
+
+// WithSort orders results by the given field, in addition to any previous sort fields.
+// Supported fields are name, date, state, category and lastOpened.
+func WithSort(field string, desc bool) ListOption {
+	return func(o *ListOptions) {
+		o.Sort = append(o.Sort, SortField{
+			Field: field,
+			Desc:  desc,
+		})
+	}
+}
+
+// WithFacets counts the matches for each value of the given fields. Supported fields are tags, dependencies,
+// category and state.
+func WithFacets(fields ...string) ListOption {
+	return func(o *ListOptions) {
+		o.Facets = append(o.Facets, fields...)
+	}
+}
+
+// WithHighlight includes highlighted fragments of the fields that matched the query.
+func WithHighlight() ListOption {
+	return func(o *ListOptions) {
+		o.Highlight = true
+	}
+}
+
 func WithSize(size int) ListOption {
 	return func(o *ListOptions) {
 		o.Size = size
@@ -95,7 +183,7 @@ func WithDateRange(startTime, endTime time.Time) ListOption {
 	}
 }
 
-func (so *ListOptions) SearchRequest() *bleve.SearchRequest {
+func (so *ListOptions) SearchRequest() (*bleve.SearchRequest, error) {
 	query := bleve.NewConjunctionQuery()
 
 	if so.Type != indextype.Unknown {
@@ -144,23 +232,88 @@ func (so *ListOptions) SearchRequest() *bleve.SearchRequest {
 		query.AddQuery(stateQuery)
 	}
 
+	for _, tag := range so.Tags {
+		tagQuery := bleve.NewTermQuery(tag)
+		tagQuery.SetField("tags")
+		query.AddQuery(tagQuery)
+	}
+
+	if so.Dependency != "" {
+		dependencyQuery := bleve.NewTermQuery(so.Dependency)
+		dependencyQuery.SetField("dependencies")
+		query.AddQuery(dependencyQuery)
+	}
+
+	if so.Pinned {
+		pinnedQuery := bleve.NewBoolFieldQuery(true)
+		pinnedQuery.SetField("pinned")
+		query.AddQuery(pinnedQuery)
+	}
+
 	if !so.StartTime.IsZero() && !so.EndTime.IsZero() {
 		dateRangeQuery := bleve.NewDateRangeQuery(so.StartTime, so.EndTime)
 		dateRangeQuery.SetField("date")
 		query.AddQuery(dateRangeQuery)
 	}
 
-	if so.Query != "" {
-		searchQuery := bleve.NewBooleanQuery()
-		fuzzy_query := bleve.NewFuzzyQuery(so.Query)
-		fuzzy_query.SetFuzziness(2)
-		searchQuery.AddShould(fuzzy_query)
-		searchQuery.AddShould(bleve.NewQueryStringQuery("*" + so.Query + "*"))
+	searchQuery, err := ParseQuery(so.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	if searchQuery != nil {
 		query.AddQuery(searchQuery)
 	} else {
 		matchAllQuery := bleve.NewMatchAllQuery()
 		query.AddQuery(matchAllQuery)
 	}
 
-	return bleve.NewSearchRequestOptions(query, so.Size, so.From, false)
+	request := bleve.NewSearchRequestOptions(query, so.Size, so.From, false)
+	if len(so.Sort) > 0 {
+		order := make([]string, 0, len(so.Sort)+1)
+		for _, sort := range so.Sort {
+			field := sortFields[sort.Field]
+			if sort.Desc {
+				field = "-" + field
+			}
+
+			order = append(order, field)
+		}
+
+		// Fall back to the ID so results with equal values keep a stable order between pages.
+		request.SortBy(append(order, "_id"))
+	}
+
+	if so.Highlight {
+		request.Highlight = bleve.NewHighlightWithStyle(html.Name)
+		for _, field := range highlightFields {
+			request.Highlight.AddField(field)
+		}
+	}
+
+	for _, facet := range so.Facets {
+		request.AddFacet(facet, bleve.NewFacetRequest(facetFields[facet], FacetSize))
+	}
+
+	return request, nil
+}
+
+func (so *ListOptions) Validate() error {
+	if so.Size < 0 || so.From < 0 {
+		return errors.New("size and from must not be negative")
+	}
+
+	for _, sort := range so.Sort {
+		if _, ok := sortFields[sort.Field]; !ok {
+			return fmt.Errorf("unsupported sort field: %s", sort.Field)
+		}
+	}
+
+	for _, facet := range so.Facets {
+		if _, ok := facetFields[facet]; !ok {
+			return fmt.Errorf("unsupported facet field: %s", facet)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,281 @@
+package listoption
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// dateLayout is the layout used for dates in search queries, e.g. updated:>2024-01-01.
+const dateLayout = "2006-01-02"
+
+// queryFields are the field names recognised before a colon. Any other text with a colon, such as a time or a
+// Windows path, is searched as written.
+var queryFields = map[string]struct{}{
+	"name":    {},
+	"tag":     {},
+	"build":   {},
+	"addon":   {},
+	"state":   {},
+	"updated": {},
+}
+
+type (
+	// QueryError is returned when a search query can't be parsed. Pos is the byte offset of the problem in the query.
+	QueryError struct {
+		Pos int
+		Msg string
+	}
+
+	// clause is a single term of a search query, such as `-tag:"client x"`.
+	clause struct {
+		pos      int
+		negate   bool
+		field    string
+		value    string
+		valuePos int
+		quoted   bool
+	}
+)
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("invalid search query at position %d: %s", e.Pos, e.Msg)
+}
+
+// ParseQuery parses a search query into a bleve query. Clauses are separated by spaces and must all match.
+// Supported clauses are free text, "quoted phrases", name:, tag:, build:, addon:, state: and updated:, with
+// updated accepting a date optionally prefixed by >, >=, < or <=. Unknown fields are treated as free text. Any clause
+// can be negated by prefixing it with -. It returns nil for an empty query.
+func ParseQuery(input string) (query.Query, error) {
+	clauses, err := lexQuery(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(clauses) == 0 {
+		return nil, nil
+	}
+
+	must := bleve.NewConjunctionQuery()
+	mustNot := bleve.NewDisjunctionQuery()
+	for _, c := range clauses {
+		q, err := c.query()
+		if err != nil {
+			return nil, err
+		}
+
+		if c.negate {
+			mustNot.AddQuery(q)
+			continue
+		}
+
+		must.AddQuery(q)
+	}
+
+	if len(mustNot.Disjuncts) == 0 {
+		return must, nil
+	}
+
+	// A query made up only of negations matches everything else.
+	if len(must.Conjuncts) == 0 {
+		must.AddQuery(bleve.NewMatchAllQuery())
+	}
+
+	result := bleve.NewBooleanQuery()
+	result.AddMust(must)
+	result.AddMustNot(mustNot)
+
+	return result, nil
+}
+
+func lexQuery(input string) ([]*clause, error) {
+	var clauses []*clause
+	i := 0
+	for i < len(input) {
+		if isSpace(input[i]) {
+			i++
+			continue
+		}
+
+		c := &clause{pos: i}
+		if input[i] == '-' {
+			c.negate = true
+			i++
+			if i == len(input) || isSpace(input[i]) {
+				return nil, &QueryError{Pos: c.pos, Msg: "expected a term after -"}
+			}
+		}
+
+		// A field name runs up to a colon, unless the term is quoted or ends first.
+		if input[i] != '"' {
+			end := i
+			for end < len(input) && !isSpace(input[end]) && input[end] != ':' && input[end] != '"' {
+				end++
+			}
+
+			if end < len(input) && input[end] == ':' {
+				if field := strings.ToLower(input[i:end]); isQueryField(field) {
+					c.field = field
+					i = end + 1
+				}
+			}
+		}
+
+		c.valuePos = i
+		if i < len(input) && input[i] == '"' {
+			end := strings.IndexByte(input[i+1:], '"')
+			if end < 0 {
+				return nil, &QueryError{Pos: i, Msg: "unterminated quoted phrase"}
+			}
+
+			c.value = input[i+1 : i+1+end]
+			c.quoted = true
+			i += end + 2
+		} else {
+			end := i
+			for end < len(input) && !isSpace(input[end]) {
+				if input[end] == '"' {
+					return nil, &QueryError{Pos: end, Msg: "unexpected quote"}
+				}
+
+				end++
+			}
+
+			c.value = input[i:end]
+			i = end
+		}
+
+		if strings.TrimSpace(c.value) == "" {
+			return nil, &QueryError{Pos: c.valuePos, Msg: "expected a value"}
+		}
+
+		clauses = append(clauses, c)
+	}
+
+	return clauses, nil
+}
+
+func (c *clause) query() (query.Query, error) {
+	switch c.field {
+	case "":
+		return c.textQuery(), nil
+	case "name":
+		if c.quoted {
+			return fieldQuery(bleve.NewMatchPhraseQuery(c.value), "name"), nil
+		}
+
+		q := bleve.NewMatchQuery(c.value)
+		q.SetField("name")
+		q.SetOperator(query.MatchQueryOperatorAnd)
+		return q, nil
+	case "tag":
+		return fieldQuery(bleve.NewTermQuery(c.value), "tags"), nil
+	case "build":
+		return fieldQuery(bleve.NewWildcardQuery(containsWildcard(c.value)), "builds"), nil
+	case "addon":
+		return fieldQuery(bleve.NewWildcardQuery(containsWildcard(c.value)), "addons"), nil
+	case "state":
+		return fieldQuery(bleve.NewTermQuery(strings.ToLower(c.value)), "sortState"), nil
+	case "updated":
+		return c.dateQuery("date")
+	}
+
+	return c.textQuery(), nil
+}
+
+// textQuery matches free text against names, allowing for typos and partially typed words, exact tags and file
+// names, and every other searchable field, such as package references and categories.
+func (c *clause) textQuery() query.Query {
+	if c.quoted {
+		return bleve.NewDisjunctionQuery(
+			fieldQuery(bleve.NewMatchPhraseQuery(c.value), "name"),
+			bleve.NewMatchPhraseQuery(c.value),
+		)
+	}
+
+	name := bleve.NewMatchQuery(c.value)
+	name.SetField("name")
+	if len(c.value) > 3 {
+		name.SetFuzziness(1)
+	}
+
+	all := bleve.NewMatchQuery(c.value)
+	all.SetOperator(query.MatchQueryOperatorAnd)
+
+	return bleve.NewDisjunctionQuery(
+		name,
+		fieldQuery(bleve.NewPrefixQuery(strings.ToLower(c.value)), "name"),
+		fieldQuery(bleve.NewTermQuery(c.value), "tags"),
+		fieldQuery(bleve.NewTermQuery(c.value), "files"),
+		all,
+	)
+}
+
+// dateQuery matches a whole day, or the days before or after it when the date is prefixed by a comparison.
+func (c *clause) dateQuery(field string) (query.Query, error) {
+	operator := ""
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(c.value, op) {
+			operator = op
+			break
+		}
+	}
+
+	date, err := time.ParseInLocation(dateLayout, c.value[len(operator):], time.Local)
+	if err != nil {
+		return nil, &QueryError{Pos: c.valuePos + len(operator), Msg: fmt.Sprintf("expected a date like %s", dateLayout)}
+	}
+
+	var start, end time.Time
+	next := date.AddDate(0, 0, 1)
+	switch operator {
+	case ">":
+		start = next
+	case ">=":
+		start = date
+	case "<":
+		end = date
+	case "<=":
+		end = next
+	default:
+		start, end = date, next
+	}
+
+	inclusive, exclusive := true, false
+	q := bleve.NewDateRangeInclusiveQuery(start, end, &inclusive, &exclusive)
+	q.SetField(field)
+
+	return q, nil
+}
+
+type fieldSetter interface {
+	query.Query
+	SetField(field string)
+}
+
+func fieldQuery(q fieldSetter, field string) query.Query {
+	q.SetField(field)
+	return q
+}
+
+// containsWildcard matches values containing the given text, unless it already has wildcards of its own.
+func containsWildcard(value string) string {
+	if strings.ContainsAny(value, "*?") {
+		return value
+	}
+
+	return "*" + value + "*"
+}
+
+func isQueryField(field string) bool {
+	_, ok := queryFields[field]
+	return ok
+}
+
+func isSpace(b byte) bool {
+	return unicode.IsSpace(rune(b))
+}
@@ -0,0 +1,224 @@
+package listoption_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/listoption"
+)
+
+// parseClauses parses a query without negations and returns its clauses.
+func parseClauses(t *testing.T, input string) []query.Query {
+	t.Helper()
+
+	q, err := listoption.ParseQuery(input)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q) failed: %v", input, err)
+	}
+
+	conjunction, ok := q.(*query.ConjunctionQuery)
+	if !ok {
+		t.Fatalf("ParseQuery(%q) = %T, want *query.ConjunctionQuery", input, q)
+	}
+
+	return conjunction.Conjuncts
+}
+
+func TestParseQueryEmpty(t *testing.T) {
+	for _, input := range []string{"", "   ", "\t\n"} {
+		q, err := listoption.ParseQuery(input)
+		if err != nil || q != nil {
+			t.Errorf("ParseQuery(%q) = %v, %v, want nil, nil", input, q, err)
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{input: "-", pos: 0},
+		{input: "lighting - tag:wip", pos: 9},
+		{input: `"shot 010`, pos: 0},
+		{input: `tag:"client x`, pos: 4},
+		{input: `shot"010`, pos: 4},
+		{input: "tag:", pos: 4},
+		{input: `name:""`, pos: 5},
+		{input: `"  "`, pos: 0},
+		{input: "updated:yesterday", pos: 8},
+		{input: "updated:2024-13-01", pos: 8},
+		{input: "updated:>=2024-1-1", pos: 10},
+		{input: "shot -updated:<", pos: 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := listoption.ParseQuery(tt.input)
+
+			var queryErr *listoption.QueryError
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("expected a QueryError, got %v", err)
+			}
+
+			if queryErr.Pos != tt.pos {
+				t.Errorf("Pos = %d, want %d (%s)", queryErr.Pos, tt.pos, queryErr.Msg)
+			}
+		})
+	}
+}
+
+func TestParseQueryNegation(t *testing.T) {
+	tests := []struct {
+		input   string
+		must    int
+		mustNot int
+	}{
+		{input: "-tag:wip", must: 0, mustNot: 1},
+		{input: "lighting -tag:wip", must: 1, mustNot: 1},
+		{input: `-"shot 010" -state:archived lighting`, must: 1, mustNot: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := listoption.ParseQuery(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			boolean, ok := q.(*query.BooleanQuery)
+			if !ok {
+				t.Fatalf("got %T, want *query.BooleanQuery", q)
+			}
+
+			// AddMust wraps the clauses in a conjunction of its own.
+			must := boolean.Must.(*query.ConjunctionQuery).Conjuncts[0].(*query.ConjunctionQuery).Conjuncts
+			if tt.must == 0 {
+				if len(must) != 1 {
+					t.Fatalf("got %d must clauses, want only match all", len(must))
+				}
+
+				if _, ok := must[0].(*query.MatchAllQuery); !ok {
+					t.Errorf("must clause is %T, want *query.MatchAllQuery", must[0])
+				}
+			} else if len(must) != tt.must {
+				t.Errorf("got %d must clauses, want %d", len(must), tt.must)
+			}
+
+			mustNot := boolean.MustNot.(*query.DisjunctionQuery).Disjuncts[0].(*query.DisjunctionQuery).Disjuncts
+			if len(mustNot) != tt.mustNot {
+				t.Errorf("got %d must not clauses, want %d", len(mustNot), tt.mustNot)
+			}
+		})
+	}
+}
+
+func TestParseQueryFields(t *testing.T) {
+	tests := []struct {
+		input string
+		field string
+	}{
+		{input: "tag:wip", field: "tags"},
+		{input: "TAG:wip", field: "tags"},
+		{input: `tag:"client x"`, field: "tags"},
+		{input: "build:4.2", field: "builds"},
+		{input: "addon:rigify", field: "addons"},
+		{input: "state:Installed", field: "sortState"},
+		{input: "name:lighting", field: "name"},
+		{input: "updated:2024-01-02", field: "date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			clauses := parseClauses(t, tt.input)
+			if len(clauses) != 1 {
+				t.Fatalf("got %d clauses, want 1", len(clauses))
+			}
+
+			fielded, ok := clauses[0].(query.FieldableQuery)
+			if !ok {
+				t.Fatalf("got %T, want a field query", clauses[0])
+			}
+
+			if fielded.Field() != tt.field {
+				t.Errorf("field = %q, want %q", fielded.Field(), tt.field)
+			}
+		})
+	}
+}
+
+func TestParseQueryUnknownFields(t *testing.T) {
+	for _, input := range []string{"shot:010", "10:30", `C:\Projects\shot.blend`, "-:x"} {
+		t.Run(input, func(t *testing.T) {
+			q, err := listoption.ParseQuery(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			value := input
+			if boolean, ok := q.(*query.BooleanQuery); ok {
+				value = input[1:]
+				q = boolean.MustNot.(*query.DisjunctionQuery).Disjuncts[0].(*query.DisjunctionQuery).Disjuncts[0]
+			} else {
+				q = q.(*query.ConjunctionQuery).Conjuncts[0]
+			}
+
+			// Free text is a disjunction across fields, starting with a match on the name.
+			text, ok := q.(*query.DisjunctionQuery)
+			if !ok {
+				t.Fatalf("got %T, want the free text disjunction", q)
+			}
+
+			name, ok := text.Disjuncts[0].(*query.MatchQuery)
+			if !ok || name.Match != value || name.FieldVal != "name" {
+				t.Errorf("name match = %+v, want %q", text.Disjuncts[0], value)
+			}
+
+			all, ok := text.Disjuncts[len(text.Disjuncts)-1].(*query.MatchQuery)
+			if !ok || all.Match != value || all.FieldVal != "" {
+				t.Errorf("last disjunct = %+v, want a match of %q across all fields", text.Disjuncts[len(text.Disjuncts)-1], value)
+			}
+		})
+	}
+}
+
+func TestParseQueryDateBounds(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)
+	next := day.AddDate(0, 0, 1)
+
+	tests := []struct {
+		input string
+		start time.Time
+		end   time.Time
+	}{
+		{input: "updated:2024-01-02", start: day, end: next},
+		{input: "updated:>2024-01-02", start: next},
+		{input: "updated:>=2024-01-02", start: day},
+		{input: "updated:<2024-01-02", end: day},
+		{input: "updated:<=2024-01-02", end: next},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			clauses := parseClauses(t, tt.input)
+			dateRange, ok := clauses[0].(*query.DateRangeQuery)
+			if !ok {
+				t.Fatalf("got %T, want *query.DateRangeQuery", clauses[0])
+			}
+
+			if !dateRange.Start.Equal(tt.start) {
+				t.Errorf("start = %v, want %v", dateRange.Start.Time, tt.start)
+			}
+
+			if !dateRange.End.Equal(tt.end) {
+				t.Errorf("end = %v, want %v", dateRange.End.Time, tt.end)
+			}
+
+			if !*dateRange.InclusiveStart || *dateRange.InclusiveEnd {
+				t.Errorf("bounds should include the start and exclude the end")
+			}
+		})
+	}
+}
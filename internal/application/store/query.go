@@ -2,11 +2,10 @@ package store
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"strconv"
 
 	"github.com/blevesearch/bleve/v2/document"
+	"github.com/blevesearch/bleve/v2/search"
 	index "github.com/blevesearch/bleve_index_api"
 	"github.com/google/uuid"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
@@ -16,20 +15,29 @@ import (
 
 var ErrNotFound = errors.New("index not found")
 
-func (s *Store) List(ctx context.Context, opts ...listoption.ListOption) ([]*types.Index, error) {
+func (s *Store) List(ctx context.Context, opts ...listoption.ListOption) (*types.IndexList, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
 	options := &listoption.ListOptions{
-		Size: 50,
+		Size: listoption.DefaultSize,
 	}
 
 	for _, o := range opts {
 		o(options)
 	}
 
-	result, err := s.index.SearchInContext(ctx, options.SearchRequest())
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	request, err := options.SearchRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.searchIndex(options.Type).SearchInContext(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +48,8 @@ func (s *Store) List(ctx context.Context, opts ...listoption.ListOption) ([]*typ
 		"maxScore": result.MaxScore,
 	})
 
-	var indexes []*types.Index
+	var fragments map[uuid.UUID]map[string][]string
+	indexes := make([]*types.Index, 0, len(result.Hits))
 	for _, hit := range result.Hits {
 		if err := ctx.Err(); err != nil {
 			return nil, err
@@ -57,9 +66,50 @@ func (s *Store) List(ctx context.Context, opts ...listoption.ListOption) ([]*typ
 		}
 
 		indexes = append(indexes, index)
+
+		if len(hit.Fragments) > 0 {
+			if fragments == nil {
+				fragments = make(map[uuid.UUID]map[string][]string)
+			}
+
+			fragments[id] = hit.Fragments
+		}
 	}
 
-	return indexes, nil
+	return &types.IndexList{
+		Indexes:   indexes,
+		Total:     result.Total,
+		Took:      result.Took,
+		Facets:    convertFacets(result.Facets),
+		Fragments: fragments,
+	}, nil
+}
+
+func convertFacets(results search.FacetResults) map[string]*types.Facet {
+	if len(results) == 0 {
+		return nil
+	}
+
+	facets := make(map[string]*types.Facet, len(results))
+	for name, result := range results {
+		terms := make([]*types.FacetTerm, 0, result.Terms.Len())
+		for _, term := range result.Terms.Terms() {
+			terms = append(terms, &types.FacetTerm{
+				Term:  term.Term,
+				Count: term.Count,
+			})
+		}
+
+		facets[name] = &types.Facet{
+			Field:   name,
+			Total:   result.Total,
+			Missing: result.Missing,
+			Other:   result.Other,
+			Terms:   terms,
+		}
+	}
+
+	return facets
 }
 
 func (s *Store) Get(ctx context.Context, id uuid.UUID) (*types.Index, error) {
@@ -71,30 +121,39 @@ func (s *Store) get(ctx context.Context, id uuid.UUID) (*types.Index, error) {
 		return nil, err
 	}
 
-	doc, err := s.index.Document(id.String())
-	if err != nil {
-		return nil, err
+	// The type is known from the index the document is found in.
+	var doc index.Document
+	result := types.Index{ID: id}
+	for _, indexType := range indexTypes {
+		found, err := s.indexes[indexType].Document(id.String())
+		if err != nil {
+			return nil, err
+		}
+
+		if found != nil {
+			doc = found
+			result.Type = indexType
+			break
+		}
 	}
 
 	if doc == nil {
 		return nil, ErrNotFound
 	}
 
-	var result types.Index
+	decodeFields(doc, &result)
+
+	return &result, nil
+}
+
+// decodeFields fills the index from the stored fields of its document. Array fields are stored as one field per value.
+func decodeFields(doc index.Document, result *types.Index) {
 	doc.VisitFields(func(field index.Field) {
 		switch field := field.(type) {
 		case *document.TextField:
-			value := string(field.Value())
+			value := field.Text()
 
 			switch field.Name() {
-			case "id":
-				if uid, err := uuid.Parse(value); err == nil {
-					result.ID = uid
-				}
-			case "type":
-				if typeInt, err := strconv.Atoi(value); err == nil {
-					result.Type = indextype.IndexType(typeInt)
-				}
 			case "reference":
 				result.Reference = value
 			case "name":
@@ -106,13 +165,53 @@ func (s *Store) get(ctx context.Context, id uuid.UUID) (*types.Index, error) {
 			case "data":
 				result.Data = value
 			case "resources":
-				var resources []string
-				if err := json.Unmarshal([]byte(value), &resources); err == nil {
-					result.Resources = resources
-				}
+				result.Resources = append(result.Resources, value)
+			case "tags":
+				result.Tags = append(result.Tags, value)
+			case "dependencies":
+				result.Dependencies = append(result.Dependencies, value)
+			case "builds":
+				result.Builds = append(result.Builds, value)
+			case "addons":
+				result.Addons = append(result.Addons, value)
+			case "files":
+				result.Files = append(result.Files, value)
+			case "operations":
+				result.Operations = append(result.Operations, value)
+			}
+		case *document.NumericField:
+			value, err := field.Number()
+			if err != nil {
+				return
+			}
+
+			if field.Name() == "type" {
+				result.Type = indextype.IndexType(value)
+			}
+		case *document.DateTimeField:
+			value, err := field.DateTime()
+			if err != nil {
+				return
+			}
+
+			// Dates are stored as UTC, so are returned in local time, as they are written.
+			value = value.Local()
+
+			switch field.Name() {
+			case "date":
+				result.Date = value
+			case "lastOpened":
+				result.LastOpened = &value
+			}
+		case *document.BooleanField:
+			value, err := field.Boolean()
+			if err != nil {
+				return
+			}
+
+			if field.Name() == "pinned" {
+				result.Pinned = value
 			}
 		}
 	})
-
-	return &result, nil
 }
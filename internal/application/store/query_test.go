@@ -0,0 +1,154 @@
+package store_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/dispatcher"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+)
+
+func newStore(t *testing.T) *store.Store {
+	t.Helper()
+
+	d, err := dispatcher.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Retention is disabled, so the fixed dates used by the tests aren't pruned as soon as they are inserted.
+	s, err := store.New(
+		store.WithDispatcher(d),
+		store.WithRetention(indextype.Operation, 0),
+		store.WithRetention(indextype.Metric, 0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := s.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return s
+}
+
+// fullIndex returns an index with every field set, so fields added later without being decoded fail the round trip.
+func fullIndex(t *testing.T, indexType indextype.IndexType) *types.Index {
+	t.Helper()
+
+	lastOpened := time.Date(2024, 3, 4, 5, 6, 7, 890, time.Local)
+	index := &types.Index{
+		ID:           uuid.New(),
+		Type:         indexType,
+		Reference:    "github.com/rocketblend/official-library/packages/blender/4.2",
+		Name:         "Shot 010 Lighting",
+		Category:     "build",
+		State:        "installed",
+		Resources:    []string{"media/thumbnail.png", "media/splash.jpg"},
+		Tags:         []string{"client-x", "lighting"},
+		Dependencies: []string{"github.com/rocketblend/official-library/packages/blender/4.2", "github.com/x/y/packages/addon"},
+		Builds:       []string{"github.com/rocketblend/official-library/packages/blender/4.2"},
+		Addons:       []string{"github.com/x/y/packages/addon"},
+		Files:        []string{"shot_010.blend", "shot_010_lighting.blend"},
+		Operations:   []string{uuid.NewString(), uuid.NewString()},
+		Pinned:       true,
+		LastOpened:   &lastOpened,
+		Date:         time.Date(2024, 1, 2, 3, 4, 5, 678, time.Local),
+		Data:         `{"name":"Shot 010 Lighting"}`,
+	}
+
+	value := reflect.ValueOf(index).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if value.Field(i).IsZero() {
+			t.Fatalf("field %s is not set", value.Type().Field(i).Name)
+		}
+	}
+
+	return index
+}
+
+func TestGetRoundTrip(t *testing.T) {
+	for _, indexType := range []indextype.IndexType{indextype.Project, indextype.Package, indextype.Operation, indextype.Metric} {
+		t.Run(indexType.String(), func(t *testing.T) {
+			s := newStore(t)
+			want := fullIndex(t, indexType)
+
+			if err := s.Insert(context.Background(), want); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := s.Get(context.Background(), want.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Get() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestGetRoundTripDatesInLocalTime(t *testing.T) {
+	s := newStore(t)
+
+	// Zones aren't stored, so dates come back as the same instant in local time.
+	zone := time.FixedZone("UTC+10", 10*60*60)
+	date := time.Date(2024, 1, 2, 23, 30, 0, 0, zone)
+	index := &types.Index{
+		ID:         uuid.New(),
+		Type:       indextype.Project,
+		LastOpened: &date,
+		Date:       date,
+	}
+
+	if err := s.Insert(context.Background(), index); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Get(context.Background(), index.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, value := range map[string]time.Time{"Date": got.Date, "LastOpened": *got.LastOpened} {
+		if !value.Equal(date) {
+			t.Errorf("%s = %v, want %v", name, value, date)
+		}
+
+		if value.Location() != time.Local {
+			t.Errorf("%s location = %v, want %v", name, value.Location(), time.Local)
+		}
+	}
+}
+
+func TestGetRoundTripUnsetFields(t *testing.T) {
+	s := newStore(t)
+
+	want := &types.Index{
+		ID:   uuid.New(),
+		Type: indextype.Project,
+		Name: "Empty",
+	}
+
+	if err := s.Insert(context.Background(), want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Get(context.Background(), want.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
+)
+
+const (
+	// DefaultOperationRetention is how long completed operations are kept after their last update.
+	DefaultOperationRetention = 24 * time.Hour
+
+	// DefaultMetricRetention is how long metrics are kept after being recorded.
+	DefaultMetricRetention = 365 * 24 * time.Hour
+
+	// pruneInterval is the minimum time between removing expired indexes of the same type.
+	pruneInterval = time.Hour
+
+	// pruneBatchSize caps the number of expired indexes removed at once.
+	pruneBatchSize = 10000
+)
+
+// prunableStates limits pruning to indexes in the given state, for types whose indexes are in use until they reach it.
+// Running operations are only dated by their last update, so would otherwise expire while still running.
+var prunableStates = map[indextype.IndexType]string{
+	indextype.Operation: "completed",
+}
+
+func defaultRetention() map[indextype.IndexType]time.Duration {
+	return map[indextype.IndexType]time.Duration{
+		indextype.Operation: DefaultOperationRetention,
+		indextype.Metric:    DefaultMetricRetention,
+	}
+}
+
+// pruneExpired removes indexes of the given type dated before their retention period, at most once per prune interval.
+func (s *Store) pruneExpired(ctx context.Context, indexType indextype.IndexType) {
+	retention := s.retention[indexType]
+	if retention <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	s.pruneMu.Lock()
+	if now.Sub(s.prunedAt[indexType]) < pruneInterval {
+		s.pruneMu.Unlock()
+		return
+	}
+
+	s.prunedAt[indexType] = now
+	s.pruneMu.Unlock()
+
+	if err := s.prune(ctx, indexType, now.Add(-retention)); err != nil {
+		s.logger.Error("error removing expired indexes", map[string]interface{}{
+			"err":  err,
+			"type": indexType,
+		})
+	}
+}
+
+func (s *Store) prune(ctx context.Context, indexType indextype.IndexType, before time.Time) error {
+	index, err := s.indexFor(indexType)
+	if err != nil {
+		return err
+	}
+
+	dateQuery := bleve.NewDateRangeQuery(time.Time{}, before)
+	dateQuery.SetField("date")
+
+	query := bleve.NewConjunctionQuery(dateQuery)
+	if state, ok := prunableStates[indexType]; ok {
+		stateQuery := bleve.NewMatchPhraseQuery(state)
+		stateQuery.SetField("state")
+		query.AddQuery(stateQuery)
+	}
+
+	result, err := index.SearchInContext(ctx, bleve.NewSearchRequestOptions(query, pruneBatchSize, 0, false))
+	if err != nil {
+		return err
+	}
+
+	if len(result.Hits) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		id, err := uuid.Parse(hit.ID)
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	s.logger.Debug("removing expired indexes", map[string]interface{}{
+		"type":   indexType,
+		"before": before,
+		"count":  len(ids),
+	})
+
+	return s.removeBatch(ctx, ids)
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+)
+
+// snapshotPageSize is the number of indexes read or written at a time when exporting or importing.
+const snapshotPageSize = 1000
+
+// Export writes every index in the store to w as JSON lines, one index per line, ordered by type then ID.
+func (s *Store) Export(ctx context.Context, w io.Writer) error {
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+
+	count := 0
+	for _, indexType := range indexTypes {
+		for from := 0; ; from += snapshotPageSize {
+			request := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), snapshotPageSize, from, false)
+			request.SortBy([]string{"_id"})
+
+			result, err := s.indexes[indexType].SearchInContext(ctx, request)
+			if err != nil {
+				return err
+			}
+
+			for _, hit := range result.Hits {
+				id, err := uuid.Parse(hit.ID)
+				if err != nil {
+					return err
+				}
+
+				index, err := s.get(ctx, id)
+				if err != nil {
+					return err
+				}
+
+				if err := encoder.Encode(index); err != nil {
+					return err
+				}
+
+				count++
+			}
+
+			if len(result.Hits) < snapshotPageSize {
+				break
+			}
+		}
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+
+	s.logger.Debug("exported store snapshot", map[string]interface{}{
+		"count": count,
+	})
+
+	return nil
+}
+
+// Import reads indexes written by Export from r and inserts them into the store, replacing any with the same ID.
+func (s *Store) Import(ctx context.Context, r io.Reader) error {
+	decoder := json.NewDecoder(bufio.NewReader(r))
+
+	count := 0
+	indexes := make([]*types.Index, 0, snapshotPageSize)
+	for line := 1; ; line++ {
+		var index types.Index
+		if err := decoder.Decode(&index); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return fmt.Errorf("invalid index on line %d: %w", line, err)
+		}
+
+		if _, err := s.indexFor(index.Type); err != nil {
+			return fmt.Errorf("invalid index on line %d: %w", line, err)
+		}
+
+		indexes = append(indexes, &index)
+		if len(indexes) == snapshotPageSize {
+			if err := s.InsertBatch(ctx, indexes); err != nil {
+				return err
+			}
+
+			count += len(indexes)
+			indexes = make([]*types.Index, 0, snapshotPageSize)
+		}
+	}
+
+	if err := s.InsertBatch(ctx, indexes); err != nil {
+		return err
+	}
+
+	s.logger.Debug("imported store snapshot", map[string]interface{}{
+		"count": count + len(indexes),
+	})
+
+	return nil
+}
@@ -3,11 +3,15 @@ package store
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/blevesearch/bleve/v2"
 	"github.com/flowshot-io/x/pkg/logger"
 	"github.com/google/uuid"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/events"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/store/listoption"
 	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
 )
@@ -15,7 +19,20 @@ import (
 type (
 	Store struct {
 		logger types.Logger
-		index  bleve.Index
+
+		// Each type of index is kept separately, so records such as metrics don't affect library search.
+		// The alias searches across all of them.
+		indexes map[indextype.IndexType]bleve.Index
+		alias   bleve.IndexAlias
+
+		retention map[indextype.IndexType]time.Duration
+		prunedAt  map[indextype.IndexType]time.Time
+		pruneMu   sync.Mutex
+
+		// Every write bumps the revision and is kept in the change log, so clients can catch up on what they missed.
+		revision uint64
+		changes  []*types.StoreChange
+		changeMu sync.Mutex
 
 		dispatcher types.Dispatcher
 	}
@@ -23,6 +40,7 @@ type (
 	Options struct {
 		Logger     types.Logger
 		Dispatcher types.Dispatcher
+		Retention  map[indextype.IndexType]time.Duration
 	}
 
 	Option func(*Options)
@@ -40,9 +58,17 @@ func WithDispatcher(dispatcher types.Dispatcher) Option {
 	}
 }
 
+// WithRetention sets how long indexes of the given type are kept, based on their date. Zero keeps them indefinitely.
+func WithRetention(indexType indextype.IndexType, retention time.Duration) Option {
+	return func(o *Options) {
+		o.Retention[indexType] = retention
+	}
+}
+
 func New(opts ...Option) (*Store, error) {
 	options := &Options{
-		Logger: logger.NoOp(),
+		Logger:    logger.NoOp(),
+		Retention: defaultRetention(),
 	}
 
 	for _, o := range opts {
@@ -53,15 +79,32 @@ func New(opts ...Option) (*Store, error) {
 		return nil, errors.New("dispatcher service is required")
 	}
 
-	indexMapping := newIndexMapping()
-	index, err := bleve.NewMemOnly(indexMapping)
-	if err != nil {
-		return nil, err
+	indexes := make(map[indextype.IndexType]bleve.Index, len(indexTypes))
+	for _, indexType := range indexTypes {
+		indexMapping, err := newIndexMappingFor(indexType)
+		if err != nil {
+			return nil, err
+		}
+
+		index, err := bleve.NewMemOnly(indexMapping)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s index: %w", indexType, err)
+		}
+
+		indexes[indexType] = index
+	}
+
+	alias := bleve.NewIndexAlias()
+	for _, indexType := range indexTypes {
+		alias.Add(indexes[indexType])
 	}
 
 	return &Store{
 		logger:     options.Logger,
-		index:      index,
+		indexes:    indexes,
+		alias:      alias,
+		retention:  options.Retention,
+		prunedAt:   make(map[indextype.IndexType]time.Time),
 		dispatcher: options.Dispatcher,
 	}, nil
 }
@@ -71,13 +114,22 @@ func (s *Store) Insert(ctx context.Context, index *types.Index) error {
 		return err
 	}
 
-	err := s.index.Index(index.ID.String(), index)
+	s.changeMu.Lock()
+	op, err := s.insertOp(index)
 	if err != nil {
+		s.changeMu.Unlock()
 		return err
 	}
 
-	event := newEvent(index.ID, index.Type)
-	if err := s.dispatcher.EmitEvent(ctx, events.StoreInsertChannel, event); err != nil {
+	if err := s.indexes[index.Type].Index(index.ID.String(), index); err != nil {
+		s.changeMu.Unlock()
+		return err
+	}
+
+	change := s.record(op, index.ID, index.Type, index)
+	s.changeMu.Unlock()
+
+	if err := s.dispatcher.EmitEvent(ctx, events.StoreInsertChannel, newEvent(change)); err != nil {
 		s.logger.Error("error emitting event", map[string]interface{}{
 			"err": err,
 		})
@@ -89,6 +141,8 @@ func (s *Store) Insert(ctx context.Context, index *types.Index) error {
 		"resource": index.Resources,
 	})
 
+	s.pruneExpired(ctx, index.Type)
+
 	return nil
 }
 
@@ -103,7 +157,12 @@ func (s *Store) RemoveByReference(ctx context.Context, path string) error {
 		Size:       10000, // TODO: Have the search request function ignore the size if it is 0
 	}
 
-	searchResults, err := s.index.SearchInContext(ctx, listOpts.SearchRequest())
+	request, err := listOpts.SearchRequest()
+	if err != nil {
+		return err
+	}
+
+	searchResults, err := s.alias.SearchInContext(ctx, request)
 	if err != nil {
 		s.logger.Error("error searching for indexes with reference", map[string]interface{}{
 			"err": err,
@@ -123,6 +182,7 @@ func (s *Store) RemoveByReference(ctx context.Context, path string) error {
 		return ErrNotFound
 	}
 
+	ids := make([]uuid.UUID, 0, len(searchResults.Hits))
 	for _, hit := range searchResults.Hits {
 		s.logger.Debug("found index for deletion", map[string]interface{}{
 			"key":  hit.ID,
@@ -140,24 +200,36 @@ func (s *Store) RemoveByReference(ctx context.Context, path string) error {
 			continue
 		}
 
-		if err := s.remove(ctx, id); err != nil {
-			s.logger.Error("error deleting index from id", map[string]interface{}{
-				"err":  err,
-				"key":  hit.ID,
-				"path": path,
-			})
-		}
+		ids = append(ids, id)
+	}
+
+	if err := s.removeBatch(ctx, ids); err != nil {
+		s.logger.Error("error deleting indexes with reference", map[string]interface{}{
+			"err":  err,
+			"path": path,
+		})
 	}
 
 	return nil
 }
 
 func (s *Store) Close() error {
-	if s.index == nil {
+	if s.alias == nil {
 		return nil
 	}
 
-	return s.index.Close()
+	var errs []error
+	for _, indexType := range indexTypes {
+		if err := s.indexes[indexType].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s index: %w", indexType, err))
+		}
+	}
+
+	if err := s.alias.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
 }
 
 func (s *Store) remove(ctx context.Context, id uuid.UUID) error {
@@ -173,13 +245,21 @@ func (s *Store) remove(ctx context.Context, id uuid.UUID) error {
 		"resources": index.Resources,
 	})
 
-	err = s.index.Delete(id.String())
+	typeIndex, err := s.indexFor(index.Type)
 	if err != nil {
 		return err
 	}
 
-	event := newEvent(index.ID, index.Type)
-	if err := s.dispatcher.EmitEvent(ctx, events.StoreRemoveChannel, event); err != nil {
+	s.changeMu.Lock()
+	if err := typeIndex.Delete(id.String()); err != nil {
+		s.changeMu.Unlock()
+		return err
+	}
+
+	change := s.record(types.StoreOpRemove, index.ID, index.Type, nil)
+	s.changeMu.Unlock()
+
+	if err := s.dispatcher.EmitEvent(ctx, events.StoreRemoveChannel, newEvent(change)); err != nil {
 		s.logger.Error("error emitting event", map[string]interface{}{
 			"err": err,
 		})
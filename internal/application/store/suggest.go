@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/store/indextype"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+)
+
+const (
+	// SuggestionLimit is the maximum number of suggestions returned.
+	SuggestionLimit = 10
+
+	// suggestionScanLimit caps the number of dictionary terms checked for each field.
+	suggestionScanLimit = 100
+)
+
+// suggestionFields are the fields suggestions are taken from, mapped to the kind of suggestion they give.
+var suggestionFields = []struct {
+	field      string
+	kind       string
+	lowercased bool
+}{
+	{"sortName", types.SuggestionKindName, true},
+	{"tags", types.SuggestionKindTag, false},
+}
+
+// Suggest returns completions for the given prefix from the names and tags in the index's term dictionary,
+// most common first. An unknown index type suggests from every type.
+func (s *Store) Suggest(ctx context.Context, prefix string, indexType indextype.IndexType) ([]*types.Suggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, nil
+	}
+
+	var suggestions []*types.Suggestion
+	for _, f := range suggestionFields {
+		termPrefix := prefix
+		if f.lowercased {
+			termPrefix = strings.ToLower(prefix)
+		}
+
+		terms, err := s.dictionaryTerms(indexType, f.field, termPrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, term := range terms {
+			suggestion, err := s.suggestion(ctx, f.field, f.kind, term, indexType)
+			if err != nil {
+				return nil, err
+			}
+
+			if suggestion != nil {
+				suggestions = append(suggestions, suggestion)
+			}
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Count != suggestions[j].Count {
+			return suggestions[i].Count > suggestions[j].Count
+		}
+
+		return suggestions[i].Text < suggestions[j].Text
+	})
+
+	if len(suggestions) > SuggestionLimit {
+		suggestions = suggestions[:SuggestionLimit]
+	}
+
+	return suggestions, nil
+}
+
+// dictionaryTerms returns the terms of the field starting with the prefix. An index alias can't read term
+// dictionaries, so an unknown index type reads from each index in turn.
+func (s *Store) dictionaryTerms(indexType indextype.IndexType, field string, prefix string) ([]string, error) {
+	searchTypes := []indextype.IndexType{indexType}
+	if _, ok := s.indexes[indexType]; !ok {
+		searchTypes = indexTypes
+	}
+
+	seen := make(map[string]struct{})
+	var terms []string
+	for _, searchType := range searchTypes {
+		dict, err := s.indexes[searchType].FieldDictPrefix(field, []byte(prefix))
+		if err != nil {
+			return nil, err
+		}
+
+		for len(terms) < suggestionScanLimit {
+			entry, err := dict.Next()
+			if err != nil {
+				dict.Close()
+				return nil, err
+			}
+
+			if entry == nil {
+				break
+			}
+
+			if _, ok := seen[entry.Term]; !ok {
+				seen[entry.Term] = struct{}{}
+				terms = append(terms, entry.Term)
+			}
+		}
+
+		if err := dict.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return terms, nil
+}
+
+// suggestion counts the indexes of the given type holding the term, returning nil if there are none.
+// Names are indexed lowercased, so the text is taken from a matching index to keep its original case.
+func (s *Store) suggestion(ctx context.Context, field string, kind string, term string, indexType indextype.IndexType) (*types.Suggestion, error) {
+	termQuery := bleve.NewTermQuery(term)
+	termQuery.SetField(field)
+
+	result, err := s.searchIndex(indexType).SearchInContext(ctx, bleve.NewSearchRequestOptions(termQuery, 1, 0, false))
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Total == 0 {
+		return nil, nil
+	}
+
+	text := term
+	if kind == types.SuggestionKindName {
+		id, err := uuid.Parse(result.Hits[0].ID)
+		if err != nil {
+			return nil, err
+		}
+
+		index, err := s.get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		text = index.Name
+	}
+
+	return &types.Suggestion{
+		Text:  text,
+		Kind:  kind,
+		Count: int(result.Total),
+	}, nil
+}
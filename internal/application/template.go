@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend-desktop/internal/application/types"
+	"github.com/rocketblend/rocketblend/pkg/reference"
+)
+
+type (
+	ListTemplatesResult struct {
+		Templates []*types.Template `json:"templates"`
+	}
+
+	CreateTemplateOpts struct {
+		Name        string                `json:"name"`
+		Description string                `json:"description"`
+		Tags        []string              `json:"tags"`
+		Folders     []string              `json:"folders"`
+		Build       reference.Reference   `json:"build,omitempty"`
+		Addons      []reference.Reference `json:"addons"`
+	}
+
+	CreateTemplateResult struct {
+		OperationID uuid.UUID `json:"operationID"`
+	}
+
+	SaveProjectAsTemplateOpts struct {
+		ProjectID   uuid.UUID `json:"projectID"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+	}
+
+	SaveProjectAsTemplateResult struct {
+		ID uuid.UUID `json:"id"`
+	}
+)
+
+func (d *Driver) ListTemplates() (*ListTemplatesResult, error) {
+	response, err := d.portfolio.ListTemplates(context.Background())
+	if err != nil {
+		d.logger.Error("failed to list templates", map[string]interface{}{"error": err.Error()})
+		return nil, err
+	}
+
+	return &ListTemplatesResult{
+		Templates: response.Templates,
+	}, nil
+}
+
+func (d *Driver) CreateTemplate(opts CreateTemplateOpts) (*CreateTemplateResult, error) {
+	build := opts.Build
+	if build == "" {
+		defaultBuild, err := d.getDefaultBuild()
+		if err != nil {
+			return nil, err
+		}
+
+		build = defaultBuild
+	}
+
+	opid, err := d.operator.Create(d.ctx, func(ctx context.Context, opid uuid.UUID) (interface{}, error) {
+		result, err := d.portfolio.CreateTemplate(ctx, &types.CreateTemplateOpts{
+			Name:        opts.Name,
+			Description: opts.Description,
+			Tags:        opts.Tags,
+			Folders:     opts.Folders,
+			Build:       build,
+			Addons:      opts.Addons,
+		})
+		if err != nil {
+			d.logger.Error("failed to create template", map[string]interface{}{
+				"error": err.Error(),
+				"opid":  opid,
+			})
+			return nil, err
+		}
+
+		d.logger.Debug("template created", map[string]interface{}{
+			"id":   result.ID,
+			"opid": opid,
+		})
+
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateTemplateResult{
+		OperationID: opid,
+	}, nil
+}
+
+func (d *Driver) SaveProjectAsTemplate(opts SaveProjectAsTemplateOpts) (*SaveProjectAsTemplateResult, error) {
+	result, err := d.portfolio.SaveProjectAsTemplate(context.Background(), &types.SaveProjectAsTemplateOpts{
+		ProjectID:   opts.ProjectID,
+		Name:        opts.Name,
+		Description: opts.Description,
+	})
+	if err != nil {
+		d.logger.Error("failed to save project as template", map[string]interface{}{
+			"error":     err.Error(),
+			"projectID": opts.ProjectID,
+		})
+		return nil, err
+	}
+
+	return &SaveProjectAsTemplateResult{
+		ID: result.ID,
+	}, nil
+}
@@ -177,13 +177,13 @@ func (t *Tracker) list(ctx context.Context, domain string, name string, start ti
 		listoption.WithSize(10000),
 	}
 
-	indexes, err := t.store.List(ctx, opts...)
+	result, err := t.store.List(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	metrics := make([]*types.Metric, 0, len(indexes))
-	for _, index := range indexes {
+	metrics := make([]*types.Metric, 0, len(result.Indexes))
+	for _, index := range result.Indexes {
 		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
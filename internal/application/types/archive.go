@@ -0,0 +1,27 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const ArchiveManifestFileName = "manifest.json"
+
+type (
+	ArchiveFile struct {
+		Path     string `json:"path"`
+		Size     int64  `json:"size"`
+		Checksum string `json:"checksum"`
+	}
+
+	// ArchiveManifest describes the contents of an exported project, and the packages needed to restore its environment.
+	ArchiveManifest struct {
+		ID           uuid.UUID      `json:"id"`
+		Name         string         `json:"name"`
+		Folder       string         `json:"folder"`
+		Files        []*ArchiveFile `json:"files"`
+		Dependencies []*Dependency  `json:"dependencies"`
+		CreatedAt    time.Time      `json:"createdAt"`
+	}
+)
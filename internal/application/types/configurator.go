@@ -1,8 +1,19 @@
 package types
 
+import (
+	"path/filepath"
+	"strings"
+)
+
 type (
+	ProjectRootConfig struct {
+		Path           string `mapstructure:"path" json:"path" validate:"required"`
+		Label          string `mapstructure:"label" json:"label"`
+		FollowSymlinks bool   `mapstructure:"followSymlinks" json:"followSymlinks"`
+	}
+
 	ProjectConfig struct {
-		Paths []string `mapstructure:"paths"`
+		Roots []ProjectRootConfig `mapstructure:"roots" validate:"dive"`
 	}
 
 	PackageConfig struct {
@@ -29,3 +40,29 @@ type (
 		Path() string
 	}
 )
+
+// Paths returns the paths of all configured project roots, in order.
+func (c ProjectConfig) Paths() []string {
+	paths := make([]string, 0, len(c.Roots))
+	for _, root := range c.Roots {
+		paths = append(paths, root.Path)
+	}
+
+	return paths
+}
+
+// Root returns the configured project root containing the given path, if any.
+func (c ProjectConfig) Root(path string) *ProjectRootConfig {
+	for i, root := range c.Roots {
+		rel, err := filepath.Rel(root.Path, path)
+		if err != nil {
+			continue
+		}
+
+		if rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return &c.Roots[i]
+		}
+	}
+
+	return nil
+}
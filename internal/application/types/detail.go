@@ -18,5 +18,6 @@ type (
 		Name      string    `json:"name"`
 		Tags      []string  `json:"tags,omitempty"`
 		MediaPath string    `json:"mediaPath,omitempty"`
+		MainFile  string    `json:"mainFile,omitempty"`
 	}
 )
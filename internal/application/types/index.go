@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,26 +10,106 @@ import (
 	"github.com/rocketblend/rocketblend-desktop/internal/application/store/listoption"
 )
 
+const (
+	SuggestionKindName = "name"
+	SuggestionKindTag  = "tag"
+)
+
+const (
+	StoreOpInsert = "insert"
+	StoreOpUpdate = "update"
+	StoreOpRemove = "remove"
+)
+
 type (
 	Index struct {
-		ID         uuid.UUID           `json:"id,omitempty"`
-		Type       indextype.IndexType `json:"type,omitempty"`
-		Reference  string              `json:"reference,omitempty"`
-		Name       string              `json:"name,omitempty"`
-		Category   string              `json:"category,omitempty"`
-		State      string              `json:"state"`
-		Resources  []string            `json:"resources,omitempty"`
-		Operations []string            `json:"operations,omitempty"`
-		Date       time.Time           `json:"date,omitempty"`
-		Data       string              `json:"data,omitempty"`
+		ID           uuid.UUID           `json:"id,omitempty"`
+		Type         indextype.IndexType `json:"type,omitempty"`
+		Reference    string              `json:"reference,omitempty"`
+		Name         string              `json:"name,omitempty"`
+		Category     string              `json:"category,omitempty"`
+		State        string              `json:"state"`
+		Resources    []string            `json:"resources,omitempty"`
+		Tags         []string            `json:"tags,omitempty"`
+		Dependencies []string            `json:"dependencies,omitempty"`
+		Builds       []string            `json:"builds,omitempty"`
+		Addons       []string            `json:"addons,omitempty"`
+		Files        []string            `json:"files,omitempty"`
+		Operations   []string            `json:"operations,omitempty"`
+		Pinned       bool                `json:"pinned,omitempty"`
+		LastOpened   *time.Time          `json:"lastOpened,omitempty"`
+		Date         time.Time           `json:"date,omitempty"`
+		Data         string              `json:"data,omitempty"`
+	}
+
+	FacetTerm struct {
+		Term  string `json:"term"`
+		Count int    `json:"count"`
+	}
+
+	// Facet counts the matches for each value of a field, such as how many projects carry each tag.
+	Facet struct {
+		Field   string       `json:"field"`
+		Total   int          `json:"total"`
+		Missing int          `json:"missing"` // Matches without a value for the field.
+		Other   int          `json:"other"`   // Matches with a value not in the returned terms.
+		Terms   []*FacetTerm `json:"terms"`
+	}
+
+	// Suggestion is a completion for a partially typed search, such as a project name or tag.
+	Suggestion struct {
+		Text  string `json:"text"`
+		Kind  string `json:"kind"`
+		Count int    `json:"count"` // Number of indexes with this value.
+	}
+
+	SuggestResponse struct {
+		Suggestions []*Suggestion `json:"suggestions"`
+	}
+
+	// IndexList is a page of indexes matching a list query.
+	IndexList struct {
+		Indexes []*Index          `json:"indexes"`
+		Total   uint64            `json:"total"` // Number of matches across all pages.
+		Took    time.Duration     `json:"took"`
+		Facets  map[string]*Facet `json:"facets,omitempty"`
+
+		// Fragments holds highlighted snippets showing why each index matched, when requested.
+		Fragments map[uuid.UUID]map[string][]string `json:"fragments,omitempty"`
+	}
+
+	// StoreChange is a single write to the store. Every write is given the next store revision.
+	StoreChange struct {
+		Revision  uint64              `json:"revision"`
+		Op        string              `json:"op"`
+		ID        uuid.UUID           `json:"id"`
+		IndexType indextype.IndexType `json:"indexType"`
+		Index     *Index              `json:"index,omitempty"` // The written index, for inserts and updates. Left without Data by Changes.
+	}
+
+	// StoreChanges lists the changes made after a revision, oldest first.
+	StoreChanges struct {
+		Revision uint64         `json:"revision"` // Current store revision.
+		Changes  []*StoreChange `json:"changes"`
+
+		// Reset is set when changes after the requested revision are no longer kept. Clients should reload
+		// everything and continue from the current revision.
+		Reset bool `json:"reset"`
 	}
 
 	Store interface {
-		List(ctx context.Context, opts ...listoption.ListOption) ([]*Index, error)
+		List(ctx context.Context, opts ...listoption.ListOption) (*IndexList, error)
 		Get(ctx context.Context, id uuid.UUID) (*Index, error)
+		Suggest(ctx context.Context, prefix string, indexType indextype.IndexType) ([]*Suggestion, error)
 		Insert(ctx context.Context, index *Index) error
+		InsertBatch(ctx context.Context, indexes []*Index) error
 		Remove(ctx context.Context, id uuid.UUID) error
+		RemoveBatch(ctx context.Context, ids []uuid.UUID) error
 		RemoveByReference(ctx context.Context, path string) error
+		Changes(ctx context.Context, sinceRevision uint64) (*StoreChanges, error)
+
+		Export(ctx context.Context, w io.Writer) error
+		Import(ctx context.Context, r io.Reader) error
 
 		Close() error
 	}
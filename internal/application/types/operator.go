@@ -13,12 +13,17 @@ type (
 		Completed bool        `json:"completed"`
 		ErrorMsg  string      `json:"error,omitempty"`
 		Result    interface{} `json:"result,omitempty"`
+		Progress  *Progress   `json:"progress,omitempty"`
 	}
 
+	// ProgressFunc reports the progress of a long running task.
+	ProgressFunc func(progress *Progress)
+
 	Operator interface {
 		Create(ctx context.Context, opFunc func(ctx context.Context, opid uuid.UUID) (interface{}, error)) (uuid.UUID, error)
 		Get(ctx context.Context, opid uuid.UUID) (*Operation, error)
 		List(ctx context.Context, opts ...listoption.ListOption) ([]*Operation, error)
+		Progress(ctx context.Context, opid uuid.UUID, progress *Progress) error
 		Cancel(opid uuid.UUID) error
 	}
 )
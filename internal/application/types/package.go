@@ -55,12 +55,49 @@ type (
 	}
 
 	ListPackagesResponse struct {
-		Packages []*Package `json:"packages,omitempty"`
+		Packages  []*Package                        `json:"packages,omitempty"`
+		Total     uint64                            `json:"total"`
+		Took      time.Duration                     `json:"took"`
+		Facets    map[string]*Facet                 `json:"facets,omitempty"`
+		Fragments map[uuid.UUID]map[string][]string `json:"fragments,omitempty"`
 	}
 
+	// AddPackageOpts adds the package for a reference. A definition can be given to add a custom package,
+	// otherwise the definition is fetched from the reference's repository.
 	AddPackageOpts struct {
-		Reference reference.Reference `json:"reference"`
-		Update    bool                `json:"update"`
+		Reference  reference.Reference `json:"reference"`
+		Definition *Definition         `json:"definition,omitempty"`
+		Update     bool                `json:"update"`
+	}
+
+	GetPackageDefinitionOpts struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	GetPackageDefinitionResponse struct {
+		Reference  reference.Reference `json:"reference"`
+		Verified   bool                `json:"verified"`
+		Definition *Definition         `json:"definition"`
+	}
+
+	UpdatePackageDefinitionOpts struct {
+		ID         uuid.UUID   `json:"id"`
+		Definition *Definition `json:"definition"`
+	}
+
+	DeletePackageDefinitionOpts struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	// RepositoryRefresh is the outcome of pulling the latest definitions for the references known in a repository.
+	RepositoryRefresh struct {
+		Repository string                `json:"repository"`
+		References []reference.Reference `json:"references"`
+		Error      string                `json:"error,omitempty"`
+	}
+
+	RefreshPackagesResult struct {
+		Repositories []*RepositoryRefresh `json:"repositories"`
 	}
 
 	InstallPackageOpts struct {
@@ -84,16 +121,21 @@ type (
 	Catalog interface {
 		GetPackage(ctx context.Context, opts *GetPackageOpts) (*GetPackageResponse, error)
 		ListPackages(ctx context.Context, opts ...listoption.ListOption) (*ListPackagesResponse, error) // TODO: Change opts struct.
+		SuggestPackages(ctx context.Context, prefix string) (*SuggestResponse, error)
 
 		AddPackageOperation(ctx context.Context, opts *AddPackageOperationOpts) error
 		RemovePackageOperation(ctx context.Context, opts *RemovePackageOperationOpts) error
 
 		AddPackage(ctx context.Context, opts *AddPackageOpts) error
 
+		GetPackageDefinition(ctx context.Context, opts *GetPackageDefinitionOpts) (*GetPackageDefinitionResponse, error)
+		UpdatePackageDefinition(ctx context.Context, opts *UpdatePackageDefinitionOpts) error
+		DeletePackageDefinition(ctx context.Context, opts *DeletePackageDefinitionOpts) error
+
 		InstallPackage(ctx context.Context, opts *InstallPackageOpts) error
 		UninstallPackage(ctx context.Context, opts *UninstallPackageOpts) error
 
-		RefreshPackages(ctx context.Context) error
+		RefreshPackages(ctx context.Context) (*RefreshPackagesResult, error)
 
 		Close() error
 	}
@@ -23,19 +23,62 @@ type (
 		// Width     int    `json:"width"`
 	}
 
+	// BlendFile is one of the blend files found in a project's folder.
+	BlendFile struct {
+		FileName         string    `json:"fileName"`
+		Size             int64     `json:"size"`
+		ModTime          time.Time `json:"modTime"`
+		SavedWithVersion string    `json:"savedWithVersion,omitempty"`
+	}
+
+	// ProjectReference is an external file used by a project's blend file, such as a linked library or an image.
+	ProjectReference struct {
+		Type         string `json:"type"`
+		Name         string `json:"name"`
+		Path         string `json:"path"`
+		ResolvedPath string `json:"resolvedPath"`
+		Packed       bool   `json:"packed"`
+		Missing      bool   `json:"missing"`
+	}
+
 	Dependency struct {
 		Reference reference.Reference `json:"reference"`
 		Type      enums.PackageType   `json:"type"`
 	}
 
 	Project struct {
-		ID   uuid.UUID `json:"id"`
-		Name string    `json:"name"`
-		Tags []string  `json:"tags"`
-		Path string    `json:"path"`
+		ID    uuid.UUID          `json:"id"`
+		Name  string             `json:"name"`
+		Tags  []string           `json:"tags"`
+		Path  string             `json:"path"`
+		Root  string             `json:"root"`
+		State enums.ProjectState `json:"state"`
+
+		// ConflictID is the ID from the project's detail file, when it is already in use by another project.
+		// The project is indexed under an ID derived from its path until the conflict is resolved.
+		ConflictID uuid.UUID `json:"conflictID,omitempty"`
+
+		// LinkPath is the path of the symbolic link or junction the project was found through, if any.
+		LinkPath string `json:"linkPath,omitempty"`
 
 		MediaPath string `json:"mediaPath"`
-		FileName  string `json:"fileName"`
+
+		// FileName is the main blend file, opened when no other file is specified.
+		FileName   string       `json:"fileName"`
+		BlendFiles []*BlendFile `json:"blendFiles"`
+
+		// SavedWithVersion is the version of Blender that last saved the main blend file, e.g. "4.2".
+		SavedWithVersion string `json:"savedWithVersion,omitempty"`
+
+		// BrokenLinks is the number of linked libraries and images that could not be found.
+		BrokenLinks int `json:"brokenLinks"`
+
+		// Pinned and LastOpenedAt are per-user state, stored in the application directory rather than the project folder.
+		Pinned       bool       `json:"pinned"`
+		LastOpenedAt *time.Time `json:"lastOpenedAt,omitempty"`
+
+		// SavedWithNewerBuild is set when the blend file was saved with a newer version of Blender than the project's build.
+		SavedWithNewerBuild bool `json:"savedWithNewerBuild"`
 
 		Dependencies []*Dependency `json:"dependencies"`
 		Media        []*Media      `json:"media"`
@@ -46,6 +89,12 @@ type (
 		UpdatedAt time.Time `json:"updatedAt"`
 	}
 
+	// ProjectUserState is the state kept for each project on the user's machine.
+	ProjectUserState struct {
+		Pinned       bool       `json:"pinned,omitempty"`
+		LastOpenedAt *time.Time `json:"lastOpenedAt,omitempty"`
+	}
+
 	GetProjectOpts struct {
 		ID uuid.UUID `json:"id"`
 	}
@@ -54,8 +103,20 @@ type (
 		Project *Project `json:"project,omitempty"`
 	}
 
+	GetProjectReferencesOpts struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	GetProjectReferencesResponse struct {
+		References []*ProjectReference `json:"references"`
+	}
+
 	ListProjectsResponse struct {
-		Projects []*Project `json:"projects,omitempty"`
+		Projects  []*Project                        `json:"projects,omitempty"`
+		Total     uint64                            `json:"total"`
+		Took      time.Duration                     `json:"took"`
+		Facets    map[string]*Facet                 `json:"facets,omitempty"`
+		Fragments map[uuid.UUID]map[string][]string `json:"fragments,omitempty"`
 	}
 
 	CreateProjectOpts struct {
@@ -63,16 +124,60 @@ type (
 		BlendFileName string              `json:"blendFileName"`
 		Path          string              `json:"path"`
 		Build         reference.Reference `json:"build"`
+		TemplateID    uuid.UUID           `json:"templateID,omitempty"`
 	}
 
 	CreateProjectResult struct {
 		ID uuid.UUID
 	}
 
+	DuplicateProjectOpts struct {
+		ID           uuid.UUID    `json:"id"`
+		Name         string       `json:"name"`
+		Path         string       `json:"path"`
+		ExcludeMedia bool         `json:"excludeMedia"`
+		Progress     ProgressFunc `json:"-"`
+	}
+
+	DuplicateProjectResult struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	ExportProjectOpts struct {
+		ID                uuid.UUID           `json:"id"`
+		Path              string              `json:"path"`
+		Format            enums.ArchiveFormat `json:"format"` // Optional, must match the path's extension when set.
+		ExcludeMedia      bool                `json:"excludeMedia"`
+		ExcludeRenders    bool                `json:"excludeRenders"`
+		HonourIgnoreFiles bool                `json:"honourIgnoreFiles"`
+		Progress          ProgressFunc        `json:"-"`
+	}
+
+	ExportProjectResult struct {
+		Path     string           `json:"path"`
+		Manifest *ArchiveManifest `json:"manifest"`
+	}
+
+	ImportProjectOpts struct {
+		ArchivePath string       `json:"archivePath"`
+		Path        string       `json:"path"`
+		Progress    ProgressFunc `json:"-"`
+	}
+
+	ImportProjectResult struct {
+		ID       uuid.UUID        `json:"id"`
+		Path     string           `json:"path"`
+		Manifest *ArchiveManifest `json:"manifest"`
+
+		// Regenerated is set when the archived ID was already in use, and the project was given a new one.
+		Regenerated bool `json:"regenerated"`
+	}
+
 	UpdateProjectOpts struct {
-		ID   uuid.UUID `json:"id"`
-		Name *string   `json:"name"`
-		Tags *[]string `json:"tags"`
+		ID       uuid.UUID `json:"id"`
+		Name     *string   `json:"name"`
+		Tags     *[]string `json:"tags"`
+		MainFile *string   `json:"mainFile"`
 	}
 
 	AddProjectPackageOpts struct {
@@ -87,20 +192,47 @@ type (
 
 	RunProjectOpts struct {
 		ID uuid.UUID `json:"id"`
+
+		// FileName is the blend file to open, defaulting to the project's main file.
+		FileName string `json:"fileName,omitempty"`
+	}
+
+	PinProjectOpts struct {
+		ID     uuid.UUID `json:"id"`
+		Pinned bool      `json:"pinned"`
+	}
+
+	RegenerateProjectIDOpts struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	RegenerateProjectIDResult struct {
+		ID uuid.UUID `json:"id"`
 	}
 
 	Portfolio interface {
 		GetProject(ctx context.Context, opts *GetProjectOpts) (*GetProjectResponse, error)
 		ListProjects(ctx context.Context, opts ...listoption.ListOption) (*ListProjectsResponse, error)
+		SuggestProjects(ctx context.Context, prefix string) (*SuggestResponse, error)
+		GetProjectReferences(ctx context.Context, opts *GetProjectReferencesOpts) (*GetProjectReferencesResponse, error)
 
 		CreateProject(ctx context.Context, opts *CreateProjectOpts) (*CreateProjectResult, error)
+		DuplicateProject(ctx context.Context, opts *DuplicateProjectOpts) (*DuplicateProjectResult, error)
+		ExportProject(ctx context.Context, opts *ExportProjectOpts) (*ExportProjectResult, error)
+		ImportProject(ctx context.Context, opts *ImportProjectOpts) (*ImportProjectResult, error)
 		UpdateProject(ctx context.Context, opts *UpdateProjectOpts) error
 		AddProjectPackage(ctx context.Context, opts *AddProjectPackageOpts) error
 		RemoveProjectPackage(ctx context.Context, opts *RemoveProjectPackageOpts) error
+		PinProject(ctx context.Context, opts *PinProjectOpts) error
+		RegenerateProjectID(ctx context.Context, opts *RegenerateProjectIDOpts) (*RegenerateProjectIDResult, error)
 
 		//RenderProject(ctx context.Context, id uuid.UUID) error
 		RunProject(ctx context.Context, opts *RunProjectOpts) error
 
+		ListTemplates(ctx context.Context) (*ListTemplatesResponse, error)
+		CreateTemplate(ctx context.Context, opts *CreateTemplateOpts) (*CreateTemplateResult, error)
+		SaveProjectAsTemplate(ctx context.Context, opts *SaveProjectAsTemplateOpts) (*CreateTemplateResult, error)
+
 		Refresh(ctx context.Context) error
 
 		Close() error
@@ -123,12 +255,29 @@ func (p *Project) Profile() *rbtypes.Profile {
 }
 
 func (p *Project) Detail() *Detail {
+	id := p.ID
+	if p.ConflictID != uuid.Nil {
+		id = p.ConflictID
+	}
+
 	return &Detail{
-		ID:        p.ID,
+		ID:        id,
 		Name:      p.Name,
 		Tags:      p.Tags,
 		MediaPath: p.MediaPath,
+		MainFile:  p.FileName,
+	}
+}
+
+// BlendFile returns the blend file with the given name.
+func (p *Project) BlendFile(fileName string) *BlendFile {
+	for _, f := range p.BlendFiles {
+		if f.FileName == fileName {
+			return f
+		}
 	}
+
+	return nil
 }
 
 func (p *Project) HasDependency(dep reference.Reference) bool {
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"github.com/rocketblend/rocketblend/pkg/reference"
+)
+
+const TemplateFileName = "template.json"
+
+type (
+	// TemplateDetail is the manifest stored alongside each template.
+	TemplateDetail struct {
+		ID          uuid.UUID `json:"id"`
+		Name        string    `json:"name"`
+		Description string    `json:"description,omitempty"`
+		Tags        []string  `json:"tags,omitempty"`
+		Folders     []string  `json:"folders,omitempty"`
+	}
+
+	Template struct {
+		ID          uuid.UUID `json:"id"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		Tags        []string  `json:"tags"`
+		Folders     []string  `json:"folders"`
+		Path        string    `json:"path"`
+		FileName    string    `json:"fileName"`
+
+		Dependencies []*Dependency `json:"dependencies"`
+	}
+
+	ListTemplatesResponse struct {
+		Templates []*Template `json:"templates,omitempty"`
+	}
+
+	CreateTemplateOpts struct {
+		Name        string                `json:"name"`
+		Description string                `json:"description"`
+		Tags        []string              `json:"tags"`
+		Folders     []string              `json:"folders"`
+		Build       reference.Reference   `json:"build"`
+		Addons      []reference.Reference `json:"addons"`
+	}
+
+	CreateTemplateResult struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	SaveProjectAsTemplateOpts struct {
+		ProjectID   uuid.UUID `json:"projectID"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+	}
+)
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// FieldError is a problem with a single field, identified by its JSON path such as sources[0].uri.
+	FieldError struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+
+	// ValidationError lists every problem found with an object, so forms can highlight each field at once.
+	ValidationError struct {
+		Errors []*FieldError `json:"errors"`
+	}
+)
+
+func (e *ValidationError) Add(field string, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, &FieldError{
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Err returns the validation error if any problems were found, otherwise nil.
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		if fieldError.Field == "" {
+			messages = append(messages, fieldError.Message)
+			continue
+		}
+
+		messages = append(messages, fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Message))
+	}
+
+	return fmt.Sprintf("validation failed: %s", strings.Join(messages, "; "))
+}
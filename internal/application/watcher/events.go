@@ -47,7 +47,9 @@ func (m eventInfo) Sys() interface{} {
 	return m.sys
 }
 
-func (s *service) watchPath(path string) error {
+// watchPath watches path under the given key. Events are reported as if they occurred within displayPath,
+// which differs from path when watching the target of a link.
+func (s *service) watchPath(key string, path string, displayPath string) error {
 	eventChannel := make(chan notify.EventInfo, 1)
 
 	err := notify.Watch(path+"/...", eventChannel, notify.All)
@@ -56,37 +58,50 @@ func (s *service) watchPath(path string) error {
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	go s.monitorEvents(eventChannel, ctx)
+	go s.monitorEvents(eventChannel, ctx, path, displayPath)
 
-	s.watchers[path] = &watcher{
+	s.watchers[key] = &watcher{
 		EventChannel: eventChannel,
 		Ctx:          ctx,
 		Cancel:       cancel,
 	}
 
 	s.logger.Debug("watching path", map[string]interface{}{
-		"path": path,
+		"path":        path,
+		"displayPath": displayPath,
 	})
 
 	return nil
 }
 
-func (s *service) unwatchPath(path string) error {
-	notify.Stop(s.watchers[path].EventChannel)
-	s.watchers[path].Cancel()
-	delete(s.watchers, path)
+func (s *service) unwatchPath(key string) error {
+	notify.Stop(s.watchers[key].EventChannel)
+	s.watchers[key].Cancel()
+	delete(s.watchers, key)
 
 	s.logger.Debug("unwatching path", map[string]interface{}{
-		"path": path,
+		"path": key,
 	})
 
 	return nil
 }
 
-func (s *service) monitorEvents(events chan notify.EventInfo, ctx context.Context) {
+func (s *service) monitorEvents(events chan notify.EventInfo, ctx context.Context, path string, displayPath string) {
 	for {
 		select {
 		case event := <-events:
+			if path != displayPath {
+				event = eventInfo{
+					event: event.Event(),
+					path:  translatePath(event.Path(), path, displayPath),
+					sys:   event.Sys(),
+				}
+			}
+
+			if event.Event()&(notify.Create|notify.Remove|notify.Rename) != 0 {
+				s.handleLinkEvent(event.Path())
+			}
+
 			// Only handle events for files we care about.
 			if s.isWatchableFile(event.Path()) {
 				s.handleEventDebounced(&objectEventInfo{
@@ -141,7 +156,7 @@ func (s *service) handleEvent(event *objectEventInfo) {
 
 	switch event.EventInfo.Event() {
 	case notify.Create, notify.Write, notify.Rename, notify.Remove:
-		if err := s.handleChange(event.ObjectPath); err != nil {
+		if err := s.handleChange(event.ObjectPath, s.linkedObjectPath(event.ObjectPath)); err != nil {
 			s.logger.Error("error while loading project", map[string]interface{}{
 				"err": err,
 			})
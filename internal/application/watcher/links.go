@@ -0,0 +1,213 @@
+package watcher
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type (
+	// link is a symbolic link or junction to a directory, found within a registered path.
+	link struct {
+		root   string // registered path the link was found in
+		path   string // path of the link itself
+		target string // canonical path of the linked directory
+	}
+)
+
+// isLink reports whether the entry is a symbolic link. Windows junctions are also reported as symbolic links.
+func isLink(d fs.DirEntry) bool {
+	return d.Type()&fs.ModeSymlink != 0
+}
+
+// resolveLink returns the canonical path of a linked directory. Links to files, broken links and
+// links to directories that have already been visited (including their parents) are not followed.
+func (s *service) resolveLink(path string, visited map[string]struct{}) (string, bool) {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		s.logger.Warn("unable to resolve link", map[string]interface{}{
+			"err":  err,
+			"path": path,
+		})
+		return "", false
+	}
+
+	info, err := os.Stat(target)
+	if err != nil || !info.IsDir() {
+		return "", false
+	}
+
+	for visitedPath := range visited {
+		if isWithin(visitedPath, target) {
+			s.logger.Debug("skipping link to visited path", map[string]interface{}{
+				"path":    path,
+				"target":  target,
+				"visited": visitedPath,
+			})
+			return "", false
+		}
+	}
+
+	return target, true
+}
+
+// addLink follows a link created after its registered path was walked.
+func (s *service) addLink(rootPath string, path string) error {
+	visited := map[string]struct{}{canonicalPath(rootPath): {}}
+	for _, link := range s.linksWithin(rootPath) {
+		visited[link.target] = struct{}{}
+	}
+
+	target, ok := s.resolveLink(path, visited)
+	if !ok {
+		return nil
+	}
+
+	s.links[path] = &link{
+		root:   rootPath,
+		path:   path,
+		target: target,
+	}
+
+	visited[target] = struct{}{}
+	objectPaths := make(map[string]struct{})
+	if err := s.walk(rootPath, target, path, true, visited, objectPaths); err != nil {
+		return fmt.Errorf("error while walking the linked path %s: %w", path, err)
+	}
+
+	s.loadObjects(objectPaths)
+
+	for _, link := range s.linksWithin(path) {
+		if err := s.watchPath(link.path, link.target, link.path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeLink stops following a link, removing any objects found through it.
+func (s *service) removeLink(link *link) error {
+	delete(s.links, link.path)
+
+	if err := s.removeObject(link.target); err != nil {
+		return fmt.Errorf("failed to remove objects in linked path %s: %w", link.path, err)
+	}
+
+	if _, exists := s.watchers[link.path]; exists {
+		if err := s.unwatchPath(link.path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleLinkEvent follows new links, and drops links that no longer exist.
+func (s *service) handleLinkEvent(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.links[path]; ok {
+		if _, err := os.Lstat(path); err == nil {
+			return
+		}
+
+		for _, link := range s.linksWithin(existing.path) {
+			if err := s.removeLink(link); err != nil {
+				s.logger.Error("failed to remove linked path", map[string]interface{}{
+					"err":  err,
+					"path": link.path,
+				})
+			}
+		}
+
+		return
+	}
+
+	rootPath := s.registeredPathFor(path)
+	if rootPath == "" || !s.paths[rootPath].followSymlinks {
+		return
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+		return
+	}
+
+	if err := s.addLink(rootPath, path); err != nil {
+		s.logger.Error("failed to follow link", map[string]interface{}{
+			"err":  err,
+			"path": path,
+		})
+	}
+}
+
+// linksWithin returns all links found within the given path, including nested links.
+func (s *service) linksWithin(path string) []*link {
+	var links []*link
+	for _, link := range s.links {
+		if isWithin(path, link.path) {
+			links = append(links, link)
+		}
+	}
+
+	return links
+}
+
+func (s *service) registeredPathFor(path string) string {
+	for registeredPath := range s.paths {
+		if isWithin(registeredPath, path) {
+			return registeredPath
+		}
+	}
+
+	return ""
+}
+
+// linkedObjectPath maps a path found through followed links onto its canonical path, which is the path objects
+// within links are loaded under. The links are used rather than the filesystem, as the path may no longer exist.
+func (s *service) linkedObjectPath(path string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.linkedObjectPathLocked(path)
+}
+
+// linkedObjectPathLocked is linkedObjectPath for callers that already hold s.mu.
+func (s *service) linkedObjectPathLocked(path string) string {
+	// Nested links are keyed by their path within the parent link, so the closest link is the one to follow.
+	var closest *link
+	for _, link := range s.links {
+		if isWithin(link.path, path) && (closest == nil || len(link.path) > len(closest.path)) {
+			closest = link
+		}
+	}
+
+	if closest == nil {
+		return path
+	}
+
+	return translatePath(path, closest.path, closest.target)
+}
+
+// translatePath maps a path within dir onto the same relative path within displayPath.
+func translatePath(path string, dir string, displayPath string) string {
+	if dir == displayPath {
+		return path
+	}
+
+	return filepath.Join(displayPath, strings.TrimPrefix(path, dir))
+}
+
+// canonicalPath returns the path with all links resolved, or the cleaned path if it cannot be resolved.
+func canonicalPath(path string) string {
+	canonical, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	return canonical
+}
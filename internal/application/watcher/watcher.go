@@ -1,9 +1,11 @@
 package watcher
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io/fs"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +14,8 @@ import (
 	"github.com/rjeczalik/notify"
 )
 
+var ErrOverlappingPath = errors.New("path overlaps with another watched path")
+
 type (
 	Watcher interface {
 		Close() error
@@ -19,23 +23,32 @@ type (
 	}
 
 	UpdateObjectFunc      func(path string) error
+	UpdateObjectsFunc     func(paths []string) error
 	RemoveObjectFunc      func(path string) error
 	ResolveObjectPathFunc func(path string) string
 	IsWatchableFileFunc   func(path string) bool
+	FollowSymlinksFunc    func(path string) bool
+
+	registeredPath struct {
+		followSymlinks bool
+	}
 
 	service struct {
 		logger logger.Logger
-		paths  map[string]struct{}
+		paths  map[string]*registeredPath
 
 		updateObjectFunc      UpdateObjectFunc
+		updateObjectsFunc     UpdateObjectsFunc
 		removeObjectFunc      RemoveObjectFunc
 		resolveObjectPathFunc ResolveObjectPathFunc
 		isWatchableFileFunc   IsWatchableFileFunc
+		followSymlinksFunc    FollowSymlinksFunc
 
 		debounceDuration time.Duration
 
 		watchers map[string]*watcher
 		events   map[string]*projectEvent
+		links    map[string]*link
 
 		mu  sync.RWMutex
 		emu sync.RWMutex
@@ -47,9 +60,11 @@ type (
 		DebounceDuration time.Duration
 
 		UpdateObjectFunc      UpdateObjectFunc
+		UpdateObjectsFunc     UpdateObjectsFunc
 		RemoveObjectFunc      RemoveObjectFunc
 		ResolveObjectPathFunc ResolveObjectPathFunc
 		IsWatchableFileFunc   IsWatchableFileFunc
+		FollowSymlinksFunc    FollowSymlinksFunc
 	}
 
 	Option func(*Options)
@@ -77,6 +92,12 @@ func WithUpdateObjectFunc(f UpdateObjectFunc) Option {
 	return func(o *Options) { o.UpdateObjectFunc = f }
 }
 
+// WithUpdateObjectsFunc sets a function that loads every object found while walking a path in one go.
+// Without it, objects are loaded one at a time using the update object function.
+func WithUpdateObjectsFunc(f UpdateObjectsFunc) Option {
+	return func(o *Options) { o.UpdateObjectsFunc = f }
+}
+
 func WithRemoveObjectFunc(f RemoveObjectFunc) Option {
 	return func(o *Options) { o.RemoveObjectFunc = f }
 }
@@ -89,6 +110,11 @@ func WithIsWatchableFileFunc(f IsWatchableFileFunc) Option {
 	return func(o *Options) { o.IsWatchableFileFunc = f }
 }
 
+// WithFollowSymlinksFunc sets a function that reports whether symbolic links and junctions should be followed within a registered path.
+func WithFollowSymlinksFunc(f FollowSymlinksFunc) Option {
+	return func(o *Options) { o.FollowSymlinksFunc = f }
+}
+
 func New(opts ...Option) (Watcher, error) {
 	options := &Options{
 		Logger:           logger.NoOp(),
@@ -104,11 +130,14 @@ func New(opts ...Option) (Watcher, error) {
 		debounceDuration:      options.DebounceDuration,
 		watchers:              make(map[string]*watcher),
 		events:                make(map[string]*projectEvent),
-		paths:                 make(map[string]struct{}),
+		links:                 make(map[string]*link),
+		paths:                 make(map[string]*registeredPath),
 		updateObjectFunc:      options.UpdateObjectFunc,
+		updateObjectsFunc:     options.UpdateObjectsFunc,
 		removeObjectFunc:      options.RemoveObjectFunc,
 		resolveObjectPathFunc: options.ResolveObjectPathFunc,
 		isWatchableFileFunc:   options.IsWatchableFileFunc,
+		followSymlinksFunc:    options.FollowSymlinksFunc,
 	}
 
 	if err := s.setPaths(options.Paths...); err != nil {
@@ -136,9 +165,10 @@ func (s *service) setPaths(paths ...string) error {
 		pathMap[path] = struct{}{}
 	}
 
-	// Unregister paths not in the new set of paths
-	for path := range s.paths {
-		if _, exists := pathMap[path]; !exists {
+	// Unregister paths not in the new set of paths, or whose symlink setting has changed
+	for path, registered := range s.paths {
+		_, exists := pathMap[path]
+		if !exists || registered.followSymlinks != s.followSymlinks(path) {
 			if err := s.unregisterPath(path); err != nil {
 				s.logger.Error("failed to unregister path", map[string]interface{}{"path": path, "error": err})
 			}
@@ -174,7 +204,7 @@ func (s *service) Close() error {
 		}
 	}
 
-	s.paths = make(map[string]struct{})
+	s.paths = make(map[string]*registeredPath)
 
 	if len(closeErrors) > 0 {
 		return fmt.Errorf("close completed with errors: %s", strings.Join(closeErrors, "; "))
@@ -184,55 +214,127 @@ func (s *service) Close() error {
 }
 
 func (s *service) registerPath(path string) error {
-	// Check if the path is already registered or it is a subpath of a registered path
+	// Check if the path is already registered, or overlaps with a registered path
 	for registeredPath := range s.paths {
-		rel, err := filepath.Rel(registeredPath, path)
-		if err != nil || !strings.HasPrefix(rel, "..") {
-			return fmt.Errorf("path %s is already registered or is a subpath of a registered path", path)
+		if overlaps(registeredPath, path) {
+			return fmt.Errorf("%w: %s and %s", ErrOverlappingPath, path, registeredPath)
 		}
 	}
 
+	followSymlinks := s.followSymlinks(path)
+
 	// Walk the file tree starting at 'path'
-	if err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+	objectPaths := make(map[string]struct{})
+	if err := s.walk(path, path, path, followSymlinks, map[string]struct{}{canonicalPath(path): {}}, objectPaths); err != nil {
+		return fmt.Errorf("error while walking the path %s: %w", path, err)
+	}
+
+	// Load the objects found while walking
+	s.loadObjects(objectPaths)
+
+	// Watch the path
+	if err := s.watchPath(path, path, path); err != nil {
+		return fmt.Errorf("failed to watch path %s: %w", path, err)
+	}
+
+	// Watch any linked directories found while walking
+	for _, link := range s.linksWithin(path) {
+		if err := s.watchPath(link.path, link.target, link.path); err != nil {
+			s.logger.Error("failed to watch linked path", map[string]interface{}{
+				"err":    err,
+				"path":   link.path,
+				"target": link.target,
+			})
+		}
+	}
+
+	// Add the path to the registered paths map
+	s.paths[path] = &registeredPath{
+		followSymlinks: followSymlinks,
+	}
+
+	return nil
+}
+
+// walk visits every file within dir, reporting them as if they were located at displayPath, and collects
+// the objects they belong to. Links to directories are followed when enabled, unless the target has already been visited.
+func (s *service) walk(rootPath string, dir string, displayPath string, followSymlinks bool, visited map[string]struct{}, objectPaths map[string]struct{}) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return fmt.Errorf("error accessing path %s: %w", path, err)
 		}
 
-		if s.isWatchableFileFunc != nil && s.isWatchableFileFunc(path) {
-			objectPath := s.resolveObjectPath(path)
+		objectPath := translatePath(path, dir, displayPath)
 
-			// Assume handleEventDebounced and updateObject are appropriately adjusted to handle the map-based structure
-			s.handleEventDebounced(&objectEventInfo{
-				ObjectPath: objectPath,
-				EventInfo: eventInfo{
-					path:  path,
-					event: notify.Write,
-				},
-			})
+		if followSymlinks && path != dir && isLink(d) {
+			target, ok := s.resolveLink(path, visited)
+			if ok {
+				s.links[objectPath] = &link{
+					root:   rootPath,
+					path:   objectPath,
+					target: target,
+				}
+
+				visited[target] = struct{}{}
+				return s.walk(rootPath, target, objectPath, followSymlinks, visited, objectPaths)
+			}
+		}
 
-			// Trigger initial update
-			if err := s.handleChange(objectPath); err != nil {
-				s.logger.Error("failed to update watched object", map[string]interface{}{
-					"err":  err,
-					"path": path,
-				})
+		if s.isWatchableFileFunc != nil && s.isWatchableFileFunc(objectPath) {
+			if resolved := s.resolveObjectPath(objectPath); resolved != "" {
+				objectPaths[resolved] = struct{}{}
 			}
 		}
 
 		return nil
-	}); err != nil {
-		return fmt.Errorf("error while walking the path %s: %w", path, err)
+	})
+}
+
+// loadObjects loads the objects found while walking a path, in a single batch when supported.
+func (s *service) loadObjects(objectPaths map[string]struct{}) {
+	if len(objectPaths) == 0 {
+		return
 	}
 
-	// Watch the path
-	if err := s.watchPath(path); err != nil {
-		return fmt.Errorf("failed to watch path %s: %w", path, err)
+	if s.updateObjectsFunc != nil {
+		paths := make([]string, 0, len(objectPaths))
+		for objectPath := range objectPaths {
+			paths = append(paths, objectPath)
+		}
+
+		sort.Strings(paths)
+		if err := s.updateObjectsFunc(paths); err != nil {
+			s.logger.Error("failed to update watched objects", map[string]interface{}{
+				"err":   err,
+				"count": len(paths),
+			})
+		}
+
+		return
 	}
 
-	// Add the path to the registered paths map
-	s.paths[path] = struct{}{}
+	for objectPath := range objectPaths {
+		s.loadInitial(objectPath)
+	}
+}
 
-	return nil
+func (s *service) loadInitial(objectPath string) {
+	// Assume handleEventDebounced and updateObject are appropriately adjusted to handle the map-based structure
+	s.handleEventDebounced(&objectEventInfo{
+		ObjectPath: objectPath,
+		EventInfo: eventInfo{
+			path:  objectPath,
+			event: notify.Write,
+		},
+	})
+
+	// Trigger initial update. Objects are loaded while s.mu is held, so the canonical path is resolved here.
+	if err := s.handleChange(objectPath, s.linkedObjectPathLocked(objectPath)); err != nil {
+		s.logger.Error("failed to update watched object", map[string]interface{}{
+			"err":  err,
+			"path": objectPath,
+		})
+	}
 }
 
 func (s *service) unregisterPath(path string) error {
@@ -243,6 +345,17 @@ func (s *service) unregisterPath(path string) error {
 	// Remove the path from the registered paths
 	delete(s.paths, path)
 
+	// Stop following any links within the unregistered path.
+	for _, link := range s.linksWithin(path) {
+		if err := s.removeLink(link); err != nil {
+			s.logger.Error("failed to remove linked path", map[string]interface{}{
+				"err":    err,
+				"path":   link.path,
+				"target": link.target,
+			})
+		}
+	}
+
 	// Remove indexed projects within unregistered path.
 	if err := s.removeObject(path); err != nil {
 		return fmt.Errorf("failed to remove projects in path %s: %w", path, err)
@@ -256,11 +369,12 @@ func (s *service) unregisterPath(path string) error {
 	return nil
 }
 
-func (s *service) handleChange(path string) error {
+// handleChange updates the object at path, removing it under objectPath, its canonical path, if it fails to load.
+func (s *service) handleChange(path string, objectPath string) error {
 	if s.updateObjectFunc != nil {
 		if err := s.updateObjectFunc(path); err != nil {
 			// Remove object if it fails to create/update.
-			if rerr := s.removeObjectFunc(path); rerr != nil {
+			if rerr := s.removeObject(objectPath); rerr != nil {
 				s.logger.Error("failed to remove object", map[string]interface{}{"error": rerr})
 			}
 
@@ -279,6 +393,14 @@ func (s *service) removeObject(path string) error {
 	return nil
 }
 
+func (s *service) followSymlinks(path string) bool {
+	if s.followSymlinksFunc != nil {
+		return s.followSymlinksFunc(path)
+	}
+
+	return false
+}
+
 func (s *service) resolveObjectPath(path string) string {
 	if s.resolveObjectPathFunc != nil {
 		return s.resolveObjectPathFunc(path)
@@ -286,3 +408,30 @@ func (s *service) resolveObjectPath(path string) string {
 
 	return path
 }
+
+// ValidatePaths checks that none of the given paths are the same as, or nested within, one another.
+func ValidatePaths(paths ...string) error {
+	for i := 0; i < len(paths); i++ {
+		for j := i + 1; j < len(paths); j++ {
+			if overlaps(paths[i], paths[j]) {
+				return fmt.Errorf("%w: %s and %s", ErrOverlappingPath, paths[j], paths[i])
+			}
+		}
+	}
+
+	return nil
+}
+
+// overlaps reports whether either path is equal to, or contained within, the other.
+func overlaps(a string, b string) bool {
+	return isWithin(a, b) || isWithin(b, a)
+}
+
+func isWithin(parent string, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(parent), filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
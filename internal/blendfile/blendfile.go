@@ -0,0 +1,348 @@
+package blendfile
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	magic = "BLENDER"
+
+	// EndCode marks the last block in a file.
+	EndCode = "ENDB"
+
+	// MaxDataSize is the largest block Data will read. The blocks read for thumbnails, libraries and the SDNA are far
+	// smaller, so anything larger is treated as a corrupt file rather than allocated.
+	MaxDataSize = 64 << 20
+)
+
+var (
+	ErrInvalidFile   = errors.New("not a blend file")
+	ErrBlockTooLarge = errors.New("blend file block is too large to read")
+
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+type (
+	// Header is the file header found at the start of every blend file.
+	Header struct {
+		PointerSize       int
+		ByteOrder         binary.ByteOrder
+		Version           int // Blender version that saved the file, e.g. 402 for 4.2.
+		FileFormatVersion int
+	}
+
+	// BlockHeader describes a block of data within a blend file.
+	BlockHeader struct {
+		Code       string
+		Size       int64
+		OldPointer uint64
+		SDNAIndex  int
+		Count      int64
+	}
+
+	// Reader reads the blocks of a blend file in order. Compressed files are decompressed as they are read.
+	Reader struct {
+		Header Header
+
+		file    *os.File
+		reader  *bufio.Reader
+		closers []io.Closer
+
+		block     *BlockHeader
+		remaining int64
+	}
+)
+
+// Open opens a blend file and reads its header.
+func Open(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Reader{
+		file: file,
+	}
+
+	if err := r.init(); err != nil {
+		r.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ReadHeader returns the header of a blend file without reading any blocks.
+func ReadHeader(path string) (*Header, error) {
+	r, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return &r.Header, nil
+}
+
+// Next advances to the next block, skipping any unread data in the current block.
+// It returns io.EOF once the end of the file has been reached.
+func (r *Reader) Next() (*BlockHeader, error) {
+	if r.remaining > 0 {
+		if _, err := io.CopyN(io.Discard, r.reader, r.remaining); err != nil {
+			return nil, err
+		}
+
+		r.remaining = 0
+	}
+
+	if r.block != nil && r.block.Code == EndCode {
+		return nil, io.EOF
+	}
+
+	block, err := r.readBlockHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	r.block = block
+	r.remaining = block.Size
+
+	if block.Code == EndCode {
+		return nil, io.EOF
+	}
+
+	return block, nil
+}
+
+// Data reads the data of the current block.
+func (r *Reader) Data() ([]byte, error) {
+	if r.block == nil {
+		return nil, errors.New("no current block")
+	}
+
+	if r.remaining > MaxDataSize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrBlockTooLarge, r.remaining)
+	}
+
+	// Read without trusting the size up front, so a block claiming more data than the file holds fails without
+	// allocating it.
+	data, err := io.ReadAll(io.LimitReader(r.reader, r.remaining))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) < r.remaining {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	r.remaining = 0
+
+	return data, nil
+}
+
+func (r *Reader) Close() error {
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		r.closers[i].Close()
+	}
+
+	return r.file.Close()
+}
+
+func (h *Header) String() string {
+	return fmt.Sprintf("%d.%d", h.Version/100, h.Version%100)
+}
+
+func (r *Reader) init() error {
+	buffered := bufio.NewReader(r.file)
+	prefix, err := buffered.Peek(len(zstdMagic))
+	if err != nil {
+		return ErrInvalidFile
+	}
+
+	var reader io.Reader = buffered
+	switch {
+	case bytes.HasPrefix(prefix, gzipMagic):
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return err
+		}
+
+		r.closers = append(r.closers, gz)
+		reader = gz
+	case bytes.HasPrefix(prefix, zstdMagic):
+		decoder, err := zstd.NewReader(buffered)
+		if err != nil {
+			return err
+		}
+
+		r.closers = append(r.closers, decoder.IOReadCloser())
+		reader = decoder
+	}
+
+	r.reader = bufio.NewReader(reader)
+
+	header, err := readHeader(r.reader)
+	if err != nil {
+		return err
+	}
+
+	r.Header = *header
+
+	return nil
+}
+
+// readHeader reads either the original 12 byte header (e.g. "BLENDER-v402"), or the header used
+// from Blender 5.0 onwards, which records its own size and a file format version (e.g. "BLENDER17-01v0500").
+func readHeader(reader *bufio.Reader) (*Header, error) {
+	buf := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(reader, buf); err != nil || string(buf[:len(magic)]) != magic {
+		return nil, ErrInvalidFile
+	}
+
+	switch buf[len(magic)] {
+	case '_', '-':
+		rest := make([]byte, 4)
+		if _, err := io.ReadFull(reader, rest); err != nil {
+			return nil, ErrInvalidFile
+		}
+
+		byteOrder, err := parseByteOrder(rest[0])
+		if err != nil {
+			return nil, err
+		}
+
+		version, err := strconv.Atoi(string(rest[1:]))
+		if err != nil {
+			return nil, ErrInvalidFile
+		}
+
+		pointerSize := 4
+		if buf[len(magic)] == '-' {
+			pointerSize = 8
+		}
+
+		return &Header{
+			PointerSize: pointerSize,
+			ByteOrder:   byteOrder,
+			Version:     version,
+		}, nil
+	}
+
+	sizeDigits := make([]byte, 1)
+	if _, err := io.ReadFull(reader, sizeDigits); err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	headerSize, err := strconv.Atoi(string([]byte{buf[len(magic)], sizeDigits[0]}))
+	if err != nil || headerSize < len(magic)+10 {
+		return nil, ErrInvalidFile
+	}
+
+	// Remaining: '-', format version (2 digits), byte order, Blender version.
+	rest := make([]byte, headerSize-len(magic)-2)
+	if _, err := io.ReadFull(reader, rest); err != nil || rest[0] != '-' {
+		return nil, ErrInvalidFile
+	}
+
+	fileFormatVersion, err := strconv.Atoi(string(rest[1:3]))
+	if err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	byteOrder, err := parseByteOrder(rest[3])
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := strconv.Atoi(string(rest[4:]))
+	if err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	return &Header{
+		PointerSize:       8,
+		ByteOrder:         byteOrder,
+		Version:           version,
+		FileFormatVersion: fileFormatVersion,
+	}, nil
+}
+
+func parseByteOrder(b byte) (binary.ByteOrder, error) {
+	switch b {
+	case 'v':
+		return binary.LittleEndian, nil
+	case 'V':
+		return binary.BigEndian, nil
+	}
+
+	return nil, ErrInvalidFile
+}
+
+func (r *Reader) readBlockHeader() (*BlockHeader, error) {
+	order := r.Header.ByteOrder
+
+	// Files written with the newer header use 64 bit sizes, with the SDNA index before the pointer.
+	if r.Header.FileFormatVersion > 0 {
+		buf := make([]byte, 32)
+		if _, err := io.ReadFull(r.reader, buf); err != nil {
+			return nil, err
+		}
+
+		return validateBlockHeader(&BlockHeader{
+			Code:       blockCode(buf[0:4]),
+			SDNAIndex:  int(int32(order.Uint32(buf[4:8]))),
+			OldPointer: order.Uint64(buf[8:16]),
+			Size:       int64(order.Uint64(buf[16:24])),
+			Count:      int64(order.Uint64(buf[24:32])),
+		})
+	}
+
+	buf := make([]byte, 16+r.Header.PointerSize)
+	if _, err := io.ReadFull(r.reader, buf); err != nil {
+		return nil, err
+	}
+
+	var oldPointer uint64
+	if r.Header.PointerSize == 8 {
+		oldPointer = order.Uint64(buf[8:16])
+	} else {
+		oldPointer = uint64(order.Uint32(buf[8:12]))
+	}
+
+	rest := buf[8+r.Header.PointerSize:]
+	return validateBlockHeader(&BlockHeader{
+		Code:       blockCode(buf[0:4]),
+		Size:       int64(int32(order.Uint32(buf[4:8]))),
+		OldPointer: oldPointer,
+		SDNAIndex:  int(int32(order.Uint32(rest[0:4]))),
+		Count:      int64(int32(order.Uint32(rest[4:8]))),
+	})
+}
+
+// validateBlockHeader rejects headers with negative sizes, which only come from corrupt files.
+func validateBlockHeader(block *BlockHeader) (*BlockHeader, error) {
+	if block.Size < 0 || block.Count < 0 {
+		return nil, fmt.Errorf("%w: invalid size for block %q", ErrInvalidFile, block.Code)
+	}
+
+	return block, nil
+}
+
+// blockCode trims the trailing zero bytes from two letter codes such as "DATA" or "OB\x00\x00".
+func blockCode(b []byte) string {
+	return string(bytes.TrimRight(b, "\x00"))
+}
@@ -0,0 +1,211 @@
+package blendfile
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	// headerV0 is the original 12 byte header, with 8 byte pointers and little endian data.
+	headerV0 = "BLENDER-v402"
+
+	// headerV1 is the header used from Blender 5.0 onwards.
+	headerV1 = "BLENDER17-01v0500"
+)
+
+// encodeBlockHeader encodes a little endian block header in the layout used by the given file header.
+func encodeBlockHeader(header string, code string, sdnaIndex int, size int64) []byte {
+	order := binary.LittleEndian
+	codeBytes := make([]byte, 4)
+	copy(codeBytes, code)
+
+	if header == headerV1 {
+		buf := make([]byte, 32)
+		copy(buf[0:4], codeBytes)
+		order.PutUint32(buf[4:8], uint32(sdnaIndex))
+		order.PutUint64(buf[16:24], uint64(size))
+		order.PutUint64(buf[24:32], 1)
+		return buf
+	}
+
+	buf := make([]byte, 24)
+	copy(buf[0:4], codeBytes)
+	order.PutUint32(buf[4:8], uint32(size))
+	order.PutUint32(buf[16:20], uint32(sdnaIndex))
+	order.PutUint32(buf[20:24], 1)
+	return buf
+}
+
+// encodeBlock encodes a block header followed by its data.
+func encodeBlock(header string, code string, sdnaIndex int, data []byte) []byte {
+	return append(encodeBlockHeader(header, code, sdnaIndex, int64(len(data))), data...)
+}
+
+// writeBlendFile writes the file header followed by the given blocks and returns the path of the file.
+func writeBlendFile(t *testing.T, header string, blocks ...[]byte) string {
+	t.Helper()
+
+	data := []byte(header)
+	for _, block := range blocks {
+		data = append(data, block...)
+	}
+
+	path := filepath.Join(t.TempDir(), "test.blend")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// readAll reads the data of every block in the file, returning the first error.
+func readAll(path string) error {
+	r, err := Open(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for {
+		if _, err := r.Next(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+
+		if _, err := r.Data(); err != nil {
+			return err
+		}
+	}
+}
+
+func TestReadHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   *Header
+		hasErr bool
+	}{
+		{name: "v0 64 bit", input: headerV0, want: &Header{PointerSize: 8, ByteOrder: binary.LittleEndian, Version: 402}},
+		{name: "v0 32 bit big endian", input: "BLENDER_V279", want: &Header{PointerSize: 4, ByteOrder: binary.BigEndian, Version: 279}},
+		{name: "v1", input: headerV1, want: &Header{PointerSize: 8, ByteOrder: binary.LittleEndian, Version: 500, FileFormatVersion: 1}},
+		{name: "bad magic", input: "BLENDRR-v402", hasErr: true},
+		{name: "bad pointer size", input: "BLENDER+v402", hasErr: true},
+		{name: "bad byte order", input: "BLENDER-x402", hasErr: true},
+		{name: "bad version", input: "BLENDER-v4x2", hasErr: true},
+		{name: "v0 truncated", input: "BLENDER-v4", hasErr: true},
+		{name: "v1 truncated", input: "BLENDER17-01v05", hasErr: true},
+		{name: "v1 header size too small", input: "BLENDER09-01v0500", hasErr: true},
+		{name: "v1 missing separator", input: "BLENDER17+01v0500", hasErr: true},
+		{name: "v1 bad format version", input: "BLENDER17-0xv0500", hasErr: true},
+		{name: "empty", input: "", hasErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, err := readHeader(bufio.NewReader(strings.NewReader(tt.input)))
+			if tt.hasErr {
+				if !errors.Is(err, ErrInvalidFile) {
+					t.Fatalf("expected ErrInvalidFile, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *header != *tt.want {
+				t.Errorf("got %+v, want %+v", header, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenCompressed(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte(headerV0))
+	gz.Write(encodeBlock(headerV0, "TEST", 0, []byte{1, 2, 3}))
+	gz.Write(encodeBlock(headerV0, EndCode, 0, nil))
+	gz.Close()
+
+	path := filepath.Join(t.TempDir(), "test.blend")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := readAll(path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadBlocks(t *testing.T) {
+	for _, header := range []string{headerV0, headerV1} {
+		end := encodeBlock(header, EndCode, 0, nil)
+
+		tests := []struct {
+			name   string
+			blocks [][]byte
+			err    error
+		}{
+			{
+				name:   "valid",
+				blocks: [][]byte{encodeBlock(header, "TEST", 0, []byte{1, 2, 3}), end},
+			},
+			{
+				name:   "negative size",
+				blocks: [][]byte{encodeBlockHeader(header, "TEST", 0, -1), end},
+				err:    ErrInvalidFile,
+			},
+			{
+				name:   "oversized",
+				blocks: [][]byte{encodeBlockHeader(header, "TEST", 0, MaxDataSize+1), end},
+				err:    ErrBlockTooLarge,
+			},
+			{
+				name:   "size past end of file",
+				blocks: [][]byte{encodeBlockHeader(header, "TEST", 0, 1024), {1, 2, 3}},
+				err:    io.ErrUnexpectedEOF,
+			},
+			{
+				name:   "truncated block header",
+				blocks: [][]byte{encodeBlock(header, "TEST", 0, nil)[:10]},
+				err:    io.ErrUnexpectedEOF,
+			},
+			{
+				name:   "missing end block",
+				blocks: [][]byte{encodeBlock(header, "TEST", 0, []byte{1})},
+				err:    io.ErrUnexpectedEOF,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(header+"/"+tt.name, func(t *testing.T) {
+				err := readAll(writeBlendFile(t, header, tt.blocks...))
+				if tt.err == nil {
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					return
+				}
+
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+			})
+		}
+	}
+}
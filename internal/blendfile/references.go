@@ -0,0 +1,162 @@
+package blendfile
+
+import (
+	"io"
+)
+
+const (
+	ReferenceTypeLibrary = "library"
+	ReferenceTypeImage   = "image"
+
+	libraryCode = "LI"
+	imageCode   = "IM"
+)
+
+type (
+	// Reference is an external file used by a blend file: either a linked library, or an image.
+	// Paths are returned as stored, so may be relative to the blend file (prefixed with "//").
+	Reference struct {
+		Type   string
+		Name   string
+		Path   string
+		Packed bool
+	}
+
+	idBlock struct {
+		code      string
+		sdnaIndex int
+		data      []byte
+	}
+)
+
+// ReadReferences returns the linked libraries and image file paths used by a blend file.
+func ReadReferences(path string) ([]*Reference, error) {
+	r, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	// The SDNA block is written last, so blocks are kept until their layout is known.
+	var blocks []*idBlock
+	var sdna *SDNA
+	for {
+		block, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch block.Code {
+		case libraryCode, imageCode:
+			data, err := r.Data()
+			if err != nil {
+				return nil, err
+			}
+
+			blocks = append(blocks, &idBlock{
+				code:      block.Code,
+				sdnaIndex: block.SDNAIndex,
+				data:      data,
+			})
+		case SDNACode:
+			data, err := r.Data()
+			if err != nil {
+				return nil, err
+			}
+
+			if sdna, err = ParseSDNA(data, &r.Header); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if sdna == nil {
+		if len(blocks) == 0 {
+			return nil, nil
+		}
+
+		return nil, ErrInvalidSDNA
+	}
+
+	references := make([]*Reference, 0, len(blocks))
+	for _, block := range blocks {
+		reference := readReference(sdna, &r.Header, block)
+		if reference != nil {
+			references = append(references, reference)
+		}
+	}
+
+	return references, nil
+}
+
+func readReference(sdna *SDNA, header *Header, block *idBlock) *Reference {
+	s := sdna.Struct(block.sdnaIndex)
+	if s == nil {
+		return nil
+	}
+
+	pathField := filePathField(s)
+	if pathField == nil {
+		return nil
+	}
+
+	path := pathField.String(block.data)
+	if path == "" {
+		return nil
+	}
+
+	reference := &Reference{
+		Type: ReferenceTypeImage,
+		Name: idName(sdna, s, block.data),
+		Path: path,
+	}
+
+	if block.code == libraryCode {
+		reference.Type = ReferenceTypeLibrary
+	}
+
+	if packed := s.Field("packedfile"); packed != nil && packed.Pointer(block.data, header) != 0 {
+		reference.Packed = true
+	}
+
+	return reference
+}
+
+// filePathField returns the field holding the file path as stored. Before Blender 2.8 this was the "name" field,
+// with "filepath" holding the absolute path; later versions moved the absolute path to "filepath_abs".
+func filePathField(s *Struct) *Field {
+	if s.Field("filepath_abs") != nil {
+		return s.Field("filepath")
+	}
+
+	if name := s.Field("name"); name != nil && name.Type == "char" {
+		return name
+	}
+
+	return s.Field("filepath")
+}
+
+// idName returns the datablock name, without its two letter type prefix.
+func idName(sdna *SDNA, s *Struct, data []byte) string {
+	idField := s.Field("id")
+	idStruct := sdna.StructByType("ID")
+	if idField == nil || idStruct == nil {
+		return ""
+	}
+
+	nameField := idStruct.Field("name")
+	if nameField == nil || idField.Offset+idStruct.Size > len(data) {
+		return ""
+	}
+
+	name := nameField.String(data[idField.Offset:])
+	if len(name) > 2 {
+		return name[2:]
+	}
+
+	return name
+}
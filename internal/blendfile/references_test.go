@@ -0,0 +1,112 @@
+package blendfile
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// encodeLibrary encodes the data of a Library block, as laid out by librarySDNA.
+func encodeLibrary(name string, path string, packed bool) []byte {
+	data := make([]byte, 74+2048+8)
+	copy(data[8:74], "LI"+name)
+	copy(data[74:1098], path)
+	if packed {
+		binary.LittleEndian.PutUint64(data[2122:], 1)
+	}
+
+	return data
+}
+
+func TestReadReferences(t *testing.T) {
+	for _, header := range []string{headerV0, headerV1} {
+		sdna := encodeBlock(header, SDNACode, 0, librarySDNA.encode())
+		end := encodeBlock(header, EndCode, 0, nil)
+
+		tests := []struct {
+			name   string
+			blocks [][]byte
+			want   []*Reference
+			err    error
+		}{
+			{
+				name: "library",
+				blocks: [][]byte{
+					encodeBlock(header, libraryCode, 1, encodeLibrary("props", "//props.blend", false)),
+					sdna,
+					end,
+				},
+				want: []*Reference{{Type: ReferenceTypeLibrary, Name: "props", Path: "//props.blend"}},
+			},
+			{
+				name: "packed library",
+				blocks: [][]byte{
+					encodeBlock(header, libraryCode, 1, encodeLibrary("props", "//props.blend", true)),
+					sdna,
+					end,
+				},
+				want: []*Reference{{Type: ReferenceTypeLibrary, Name: "props", Path: "//props.blend", Packed: true}},
+			},
+			{
+				name: "library data shorter than its struct",
+				blocks: [][]byte{
+					encodeBlock(header, libraryCode, 1, encodeLibrary("props", "//props.blend", false)[:80]),
+					sdna,
+					end,
+				},
+				want: []*Reference{},
+			},
+			{
+				name: "unknown struct index",
+				blocks: [][]byte{
+					encodeBlock(header, libraryCode, 7, encodeLibrary("props", "//props.blend", false)),
+					sdna,
+					end,
+				},
+				want: []*Reference{},
+			},
+			{
+				name:   "no libraries or sdna",
+				blocks: [][]byte{end},
+			},
+			{
+				name: "library without sdna",
+				blocks: [][]byte{
+					encodeBlock(header, libraryCode, 1, encodeLibrary("props", "//props.blend", false)),
+					end,
+				},
+				err: ErrInvalidSDNA,
+			},
+			{
+				name: "truncated sdna",
+				blocks: [][]byte{
+					encodeBlock(header, SDNACode, 0, librarySDNA.encode()[:40]),
+					end,
+				},
+				err: ErrInvalidSDNA,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(header+"/"+tt.name, func(t *testing.T) {
+				references, err := ReadReferences(writeBlendFile(t, header, tt.blocks...))
+				if tt.err != nil {
+					if !errors.Is(err, tt.err) {
+						t.Fatalf("expected %v, got %v", tt.err, err)
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(references, tt.want) {
+					t.Errorf("got %+v, want %+v", references, tt.want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package blendfile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SDNACode is the code of the block describing the layout of every struct in the file.
+const SDNACode = "DNA1"
+
+var ErrInvalidSDNA = errors.New("invalid sdna block")
+
+type (
+	// Field is a member of a struct, as described by the file's SDNA.
+	Field struct {
+		Name   string // name without pointer or array notation, e.g. "filepath"
+		Type   string
+		Offset int
+		Size   int
+	}
+
+	Struct struct {
+		Type   string
+		Size   int
+		Fields []*Field
+	}
+
+	// SDNA describes the layout of every struct stored in a blend file.
+	SDNA struct {
+		Structs []*Struct
+	}
+
+	sdnaReader struct {
+		data   []byte
+		offset int
+		order  binary.ByteOrder
+	}
+)
+
+// ParseSDNA parses the data of a DNA1 block.
+func ParseSDNA(data []byte, header *Header) (*SDNA, error) {
+	r := &sdnaReader{data: data, order: header.ByteOrder}
+	if err := r.expect("SDNA"); err != nil {
+		return nil, err
+	}
+
+	names, err := r.section("NAME")
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := r.section("TYPE")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.expect("TLEN"); err != nil {
+		return nil, err
+	}
+
+	lengths := make([]int, len(types))
+	for i := range types {
+		length, err := r.int16()
+		if err != nil {
+			return nil, err
+		}
+
+		lengths[i] = length
+	}
+
+	r.align()
+	if err := r.expect("STRC"); err != nil {
+		return nil, err
+	}
+
+	count, err := r.int32()
+	if err != nil {
+		return nil, err
+	}
+
+	// Counts come from the file, so capacities are bounded by the data left rather than trusted.
+	sdna := &SDNA{Structs: make([]*Struct, 0, min(count, len(r.data)-r.offset))}
+	for i := 0; i < count; i++ {
+		typeIndex, err := r.int16()
+		if err != nil {
+			return nil, err
+		}
+
+		fieldCount, err := r.int16()
+		if err != nil {
+			return nil, err
+		}
+
+		if typeIndex >= len(types) {
+			return nil, ErrInvalidSDNA
+		}
+
+		s := &Struct{
+			Type: types[typeIndex],
+			Size: lengths[typeIndex],
+		}
+
+		offset := 0
+		for j := 0; j < fieldCount; j++ {
+			fieldType, err := r.int16()
+			if err != nil {
+				return nil, err
+			}
+
+			fieldName, err := r.int16()
+			if err != nil {
+				return nil, err
+			}
+
+			if fieldType >= len(types) || fieldName >= len(names) {
+				return nil, ErrInvalidSDNA
+			}
+
+			name, size := fieldSize(names[fieldName], lengths[fieldType], header.PointerSize)
+			s.Fields = append(s.Fields, &Field{
+				Name:   name,
+				Type:   types[fieldType],
+				Offset: offset,
+				Size:   size,
+			})
+
+			offset += size
+		}
+
+		sdna.Structs = append(sdna.Structs, s)
+	}
+
+	return sdna, nil
+}
+
+// Struct returns the struct at the given index, as referenced by block headers.
+func (s *SDNA) Struct(index int) *Struct {
+	if index < 0 || index >= len(s.Structs) {
+		return nil
+	}
+
+	return s.Structs[index]
+}
+
+// StructByType returns the struct with the given type name.
+func (s *SDNA) StructByType(name string) *Struct {
+	for _, st := range s.Structs {
+		if st.Type == name {
+			return st
+		}
+	}
+
+	return nil
+}
+
+// Field returns the field with the given name.
+func (s *Struct) Field(name string) *Field {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+
+	return nil
+}
+
+// String reads the field as a null terminated string from the given struct data.
+func (f *Field) String(data []byte) string {
+	if f.Offset+f.Size > len(data) {
+		return ""
+	}
+
+	value := data[f.Offset : f.Offset+f.Size]
+	if i := bytes.IndexByte(value, 0); i >= 0 {
+		value = value[:i]
+	}
+
+	return string(value)
+}
+
+// Pointer reads the field as a pointer from the given struct data.
+func (f *Field) Pointer(data []byte, header *Header) uint64 {
+	if f.Offset+header.PointerSize > len(data) {
+		return 0
+	}
+
+	if header.PointerSize == 8 {
+		return header.ByteOrder.Uint64(data[f.Offset:])
+	}
+
+	return uint64(header.ByteOrder.Uint32(data[f.Offset:]))
+}
+
+// fieldSize returns the plain name and size of a field from its SDNA name, such as "*next", "name[66]" or "(*func)()".
+func fieldSize(name string, typeSize int, pointerSize int) (string, int) {
+	size := typeSize
+	if strings.HasPrefix(name, "*") || strings.HasPrefix(name, "(*") {
+		size = pointerSize
+	}
+
+	plain := strings.TrimLeft(name, "*(")
+	if i := strings.IndexAny(plain, ")["); i >= 0 {
+		plain = plain[:i]
+	}
+
+	// Multiply out array dimensions, e.g. "mat[4][4]".
+	rest := name
+	for {
+		start := strings.IndexByte(rest, '[')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(rest[start:], ']')
+		if end < 0 {
+			break
+		}
+
+		if n, err := strconv.Atoi(rest[start+1 : start+end]); err == nil {
+			size *= n
+		}
+
+		rest = rest[start+end+1:]
+	}
+
+	return plain, size
+}
+
+func (r *sdnaReader) expect(code string) error {
+	if r.offset+len(code) > len(r.data) || string(r.data[r.offset:r.offset+len(code)]) != code {
+		return fmt.Errorf("%w: expected %s", ErrInvalidSDNA, code)
+	}
+
+	r.offset += len(code)
+	return nil
+}
+
+// section reads a list of null terminated strings, preceded by its code and count.
+func (r *sdnaReader) section(code string) ([]string, error) {
+	if err := r.expect(code); err != nil {
+		return nil, err
+	}
+
+	count, err := r.int32()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, min(count, len(r.data)-r.offset))
+	for i := 0; i < count; i++ {
+		end := bytes.IndexByte(r.data[r.offset:], 0)
+		if end < 0 {
+			return nil, ErrInvalidSDNA
+		}
+
+		values = append(values, string(r.data[r.offset:r.offset+end]))
+		r.offset += end + 1
+	}
+
+	r.align()
+	return values, nil
+}
+
+func (r *sdnaReader) int32() (int, error) {
+	if r.offset+4 > len(r.data) {
+		return 0, ErrInvalidSDNA
+	}
+
+	value := int(int32(r.order.Uint32(r.data[r.offset:])))
+	r.offset += 4
+	if value < 0 {
+		return 0, ErrInvalidSDNA
+	}
+
+	return value, nil
+}
+
+func (r *sdnaReader) int16() (int, error) {
+	if r.offset+2 > len(r.data) {
+		return 0, ErrInvalidSDNA
+	}
+
+	value := int(r.order.Uint16(r.data[r.offset:]))
+	r.offset += 2
+	return value, nil
+}
+
+func (r *sdnaReader) align() {
+	r.offset = (r.offset + 3) &^ 3
+}
@@ -0,0 +1,197 @@
+package blendfile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+type (
+	// testStruct is a struct written to a test SDNA block, with each field given as its type and name indexes.
+	testStruct struct {
+		typeIndex int
+		fields    [][2]int
+	}
+
+	// testSDNA is the content of a little endian SDNA block.
+	testSDNA struct {
+		names   []string
+		types   []string
+		lengths []int
+		structs []testStruct
+	}
+)
+
+// librarySDNA describes a Library, as written by Blender 2.8 onwards, and the ID it starts with.
+var librarySDNA = testSDNA{
+	names:   []string{"name[66]", "*next", "id", "filepath[1024]", "filepath_abs[1024]", "*packedfile"},
+	types:   []string{"char", "ID", "Library", "PackedFile"},
+	lengths: []int{1, 74, 74 + 2048 + 8, 16},
+	structs: []testStruct{
+		{typeIndex: 1, fields: [][2]int{{1, 1}, {0, 0}}},
+		{typeIndex: 2, fields: [][2]int{{1, 2}, {0, 3}, {0, 4}, {3, 5}}},
+	},
+}
+
+func (s testSDNA) encode() []byte {
+	order := binary.LittleEndian
+	var buf bytes.Buffer
+	align := func() {
+		for buf.Len()%4 != 0 {
+			buf.WriteByte(0)
+		}
+	}
+
+	section := func(code string, values []string) {
+		buf.WriteString(code)
+		binary.Write(&buf, order, int32(len(values)))
+		for _, value := range values {
+			buf.WriteString(value)
+			buf.WriteByte(0)
+		}
+
+		align()
+	}
+
+	buf.WriteString("SDNA")
+	section("NAME", s.names)
+	section("TYPE", s.types)
+
+	buf.WriteString("TLEN")
+	for _, length := range s.lengths {
+		binary.Write(&buf, order, int16(length))
+	}
+
+	align()
+	buf.WriteString("STRC")
+	binary.Write(&buf, order, int32(len(s.structs)))
+	for _, st := range s.structs {
+		binary.Write(&buf, order, int16(st.typeIndex))
+		binary.Write(&buf, order, int16(len(st.fields)))
+		for _, field := range st.fields {
+			binary.Write(&buf, order, int16(field[0]))
+			binary.Write(&buf, order, int16(field[1]))
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func TestParseSDNA(t *testing.T) {
+	header := &Header{PointerSize: 8, ByteOrder: binary.LittleEndian}
+
+	sdna, err := ParseSDNA(librarySDNA.encode(), header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	library := sdna.StructByType("Library")
+	if library == nil || sdna.Struct(1) != library {
+		t.Fatal("expected Library at index 1")
+	}
+
+	want := []Field{
+		{Name: "id", Type: "ID", Offset: 0, Size: 74},
+		{Name: "filepath", Type: "char", Offset: 74, Size: 1024},
+		{Name: "filepath_abs", Type: "char", Offset: 1098, Size: 1024},
+		{Name: "packedfile", Type: "PackedFile", Offset: 2122, Size: 8},
+	}
+
+	if len(library.Fields) != len(want) {
+		t.Fatalf("got %d fields, want %d", len(library.Fields), len(want))
+	}
+
+	for i, field := range library.Fields {
+		if *field != want[i] {
+			t.Errorf("field %d = %+v, want %+v", i, *field, want[i])
+		}
+	}
+
+	if sdna.Struct(-1) != nil || sdna.Struct(2) != nil {
+		t.Error("expected nil for structs out of range")
+	}
+}
+
+func TestParseSDNATruncated(t *testing.T) {
+	header := &Header{PointerSize: 8, ByteOrder: binary.LittleEndian}
+	data := librarySDNA.encode()
+
+	// Every prefix of a valid block is missing part of a section, and must fail rather than panic.
+	for size := 0; size < len(data); size++ {
+		if _, err := ParseSDNA(data[:size], header); !errors.Is(err, ErrInvalidSDNA) {
+			t.Fatalf("size %d: expected ErrInvalidSDNA, got %v", size, err)
+		}
+	}
+}
+
+func TestParseSDNAInvalid(t *testing.T) {
+	header := &Header{PointerSize: 8, ByteOrder: binary.LittleEndian}
+
+	badType := librarySDNA
+	badType.structs = []testStruct{{typeIndex: 4}}
+
+	badFieldType := librarySDNA
+	badFieldType.structs = []testStruct{{typeIndex: 1, fields: [][2]int{{4, 0}}}}
+
+	badFieldName := librarySDNA
+	badFieldName.structs = []testStruct{{typeIndex: 1, fields: [][2]int{{0, 6}}}}
+
+	// A count far larger than the data must fail on the data, not allocate for the count. Without any structs, the
+	// count is the last value in the block.
+	noStructs := librarySDNA
+	noStructs.structs = nil
+	hugeCount := noStructs.encode()
+	binary.LittleEndian.PutUint32(hugeCount[len(hugeCount)-4:], 0x7fffffff)
+
+	negativeCount := librarySDNA.encode()
+	binary.LittleEndian.PutUint32(negativeCount[8:12], 0xffffffff)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "wrong code", data: append([]byte("SDNB"), librarySDNA.encode()[4:]...)},
+		{name: "struct type out of range", data: badType.encode()},
+		{name: "field type out of range", data: badFieldType.encode()},
+		{name: "field name out of range", data: badFieldName.encode()},
+		{name: "huge struct count", data: hugeCount},
+		{name: "negative name count", data: negativeCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSDNA(tt.data, header); !errors.Is(err, ErrInvalidSDNA) {
+				t.Fatalf("expected ErrInvalidSDNA, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFieldSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		typeSize    int
+		pointerSize int
+		wantName    string
+		wantSize    int
+	}{
+		{name: "flag", typeSize: 2, pointerSize: 8, wantName: "flag", wantSize: 2},
+		{name: "*next", typeSize: 120, pointerSize: 8, wantName: "next", wantSize: 8},
+		{name: "*next", typeSize: 120, pointerSize: 4, wantName: "next", wantSize: 4},
+		{name: "**mat", typeSize: 120, pointerSize: 8, wantName: "mat", wantSize: 8},
+		{name: "name[66]", typeSize: 1, pointerSize: 8, wantName: "name", wantSize: 66},
+		{name: "mat[4][4]", typeSize: 4, pointerSize: 8, wantName: "mat", wantSize: 64},
+		{name: "*mtex[18]", typeSize: 120, pointerSize: 8, wantName: "mtex", wantSize: 144},
+		{name: "(*func)()", typeSize: 0, pointerSize: 8, wantName: "func", wantSize: 8},
+		{name: "name[x]", typeSize: 1, pointerSize: 8, wantName: "name", wantSize: 1},
+		{name: "name[66", typeSize: 1, pointerSize: 8, wantName: "name", wantSize: 1},
+	}
+
+	for _, tt := range tests {
+		name, size := fieldSize(tt.name, tt.typeSize, tt.pointerSize)
+		if name != tt.wantName || size != tt.wantSize {
+			t.Errorf("fieldSize(%q, %d, %d) = %q, %d, want %q, %d", tt.name, tt.typeSize, tt.pointerSize, name, size, tt.wantName, tt.wantSize)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package blendfile
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+)
+
+const thumbnailCode = "TEST"
+
+// maxThumbnailSize is the largest width or height accepted for a thumbnail. Blender saves them at 128 pixels, or 256
+// in newer versions.
+const maxThumbnailSize = 1024
+
+var ErrNoThumbnail = errors.New("blend file has no thumbnail")
+
+// ReadThumbnail returns the preview image Blender stores when saving a file.
+func ReadThumbnail(path string) (image.Image, error) {
+	r, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	for {
+		block, err := r.Next()
+		if err == io.EOF {
+			return nil, ErrNoThumbnail
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if block.Code != thumbnailCode {
+			continue
+		}
+
+		data, err := r.Data()
+		if err != nil {
+			return nil, err
+		}
+
+		return decodeThumbnail(data, &r.Header)
+	}
+}
+
+// decodeThumbnail decodes the thumbnail block: the width and height, followed by RGBA pixels from the bottom row up.
+func decodeThumbnail(data []byte, header *Header) (image.Image, error) {
+	if len(data) < 8 {
+		return nil, ErrNoThumbnail
+	}
+
+	width := int(int32(header.ByteOrder.Uint32(data[0:4])))
+	height := int(int32(header.ByteOrder.Uint32(data[4:8])))
+	if width <= 0 || height <= 0 {
+		return nil, ErrNoThumbnail
+	}
+
+	if width > maxThumbnailSize || height > maxThumbnailSize {
+		return nil, fmt.Errorf("thumbnail too large: %dx%d", width, height)
+	}
+
+	pixels := data[8:]
+	stride := width * 4
+	if height > len(pixels)/stride {
+		return nil, fmt.Errorf("thumbnail data too short: expected %d bytes, got %d", stride*height, len(pixels))
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		row := pixels[(height-1-y)*stride : (height-y)*stride]
+		copy(img.Pix[y*img.Stride:y*img.Stride+stride], row)
+	}
+
+	return img, nil
+}
@@ -0,0 +1,94 @@
+package blendfile
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"testing"
+)
+
+// encodeThumbnail encodes a thumbnail block with the given dimensions and pixel data.
+func encodeThumbnail(width int32, height int32, pixels []byte) []byte {
+	data := make([]byte, 8, 8+len(pixels))
+	binary.LittleEndian.PutUint32(data[0:4], uint32(width))
+	binary.LittleEndian.PutUint32(data[4:8], uint32(height))
+	return append(data, pixels...)
+}
+
+func TestDecodeThumbnail(t *testing.T) {
+	header := &Header{PointerSize: 8, ByteOrder: binary.LittleEndian}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		noThumb bool
+		hasErr  bool
+	}{
+		{name: "valid", data: encodeThumbnail(2, 2, make([]byte, 16))},
+		{name: "too short for dimensions", data: []byte{1, 2, 3}, noThumb: true},
+		{name: "zero width", data: encodeThumbnail(0, 2, nil), noThumb: true},
+		{name: "negative height", data: encodeThumbnail(2, -2, nil), noThumb: true},
+		{name: "too wide", data: encodeThumbnail(maxThumbnailSize+1, 1, nil), hasErr: true},
+		{name: "too tall", data: encodeThumbnail(1, maxThumbnailSize+1, nil), hasErr: true},
+		{name: "largest allowed without pixels", data: encodeThumbnail(maxThumbnailSize, maxThumbnailSize, nil), hasErr: true},
+		{name: "missing last row", data: encodeThumbnail(2, 2, make([]byte, 15)), hasErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := decodeThumbnail(tt.data, header)
+			switch {
+			case tt.noThumb:
+				if !errors.Is(err, ErrNoThumbnail) {
+					t.Fatalf("expected ErrNoThumbnail, got %v", err)
+				}
+			case tt.hasErr:
+				if err == nil || errors.Is(err, ErrNoThumbnail) {
+					t.Fatalf("expected a decode error, got %v", err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			case img.Bounds() != image.Rect(0, 0, 2, 2):
+				t.Errorf("bounds = %v, want 2x2", img.Bounds())
+			}
+		})
+	}
+}
+
+func TestReadThumbnail(t *testing.T) {
+	// Rows are stored from the bottom up, so the first row in the file is the last row of the image.
+	pixels := []byte{
+		1, 1, 1, 255, 2, 2, 2, 255,
+		3, 3, 3, 255, 4, 4, 4, 255,
+	}
+
+	for _, header := range []string{headerV0, headerV1} {
+		t.Run(header, func(t *testing.T) {
+			path := writeBlendFile(t, header,
+				encodeBlock(header, thumbnailCode, 0, encodeThumbnail(2, 2, pixels)),
+				encodeBlock(header, EndCode, 0, nil),
+			)
+
+			img, err := ReadThumbnail(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			nrgba := img.(*image.NRGBA)
+			if got := nrgba.NRGBAAt(0, 0).R; got != 3 {
+				t.Errorf("top left = %d, want 3", got)
+			}
+
+			if got := nrgba.NRGBAAt(1, 1).R; got != 2 {
+				t.Errorf("bottom right = %d, want 2", got)
+			}
+		})
+	}
+
+	t.Run("no thumbnail", func(t *testing.T) {
+		path := writeBlendFile(t, headerV0, encodeBlock(headerV0, EndCode, 0, nil))
+		if _, err := ReadThumbnail(path); !errors.Is(err, ErrNoThumbnail) {
+			t.Fatalf("expected ErrNoThumbnail, got %v", err)
+		}
+	})
+}